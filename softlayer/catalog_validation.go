@@ -0,0 +1,173 @@
+package softlayer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+// resourceDataGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff.
+// Order building functions accept it so that the same code can validate an order at plan time
+// and build the real order at apply time.
+type resourceDataGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// Returns an error if the product package can't be ordered in the data center. The error lists
+// the data centers where the package is available.
+func validatePackageDatacenter(sess *session.Session, packageId int, packageName string, datacenter string) error {
+	regions, err := services.GetProductPackageService(sess).
+		Id(packageId).
+		Mask("location[location[name]]").
+		GetRegions()
+	if err != nil {
+		return fmt.Errorf("Error retrieving available data centers of the package %s: %s", packageName, err)
+	}
+
+	datacenters := make([]string, 0, len(regions))
+	for _, region := range regions {
+		if region.Location == nil || region.Location.Location == nil || region.Location.Location.Name == nil {
+			continue
+		}
+		if *region.Location.Location.Name == datacenter {
+			return nil
+		}
+		datacenters = append(datacenters, *region.Location.Location.Name)
+	}
+	sort.Strings(datacenters)
+
+	return fmt.Errorf("The package %s is not available in the data center %s. Available data center(s) is(are) %s",
+		packageName, datacenter, strings.Join(datacenters, ", "))
+}
+
+func validateStorageType(v interface{}, k string) (ws []string, errors []error) {
+	storageType := v.(string)
+	if storageType != enduranceType && storageType != performanceType {
+		errors = append(errors, fmt.Errorf(
+			"%s should be either '%s' or '%s'", k, enduranceType, performanceType))
+	}
+	return
+}
+
+// validateStorageOrder checks the storage order against the product catalog during plan so that
+// invalid capacity, IOPS and data center combinations are reported before anything is ordered.
+// Capacity and IOPS changes of existing storage are validated as a modification order.
+func validateStorageOrder(storageProtocol string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if err := validateSnapshotSchedules(d.Get("snapshot_schedule").(*schema.Set).List()); err != nil {
+			return err
+		}
+
+		if d.Id() == "" && d.Get("origin_snapshot_id").(int) > 0 &&
+			d.NewValueKnown("origin_volume_id") && d.Get("origin_volume_id").(int) == 0 {
+			return fmt.Errorf("origin_snapshot_id can only be set together with origin_volume_id")
+		}
+
+		replaced := d.HasChange("type") || d.HasChange("datacenter") || d.HasChange("snapshot_capacity") ||
+			d.HasChange("origin_volume_id") || d.HasChange("origin_snapshot_id")
+		if d.Id() != "" && !replaced {
+			if d.HasChange("capacity") || d.HasChange("iops") {
+				return validateStorageModification(d, meta)
+			}
+			return nil
+		}
+
+		storageType := d.Get("type").(string)
+		datacenter := d.Get("datacenter").(string)
+		capacity := d.Get("capacity").(int)
+		iops := d.Get("iops").(float64)
+		snapshotCapacity := d.Get("snapshot_capacity").(int)
+
+		// Values computed from other resources are not known until apply.
+		if storageType == "" || datacenter == "" || capacity == 0 || iops == 0 {
+			return nil
+		}
+
+		sess := meta.(ProviderConfig).SoftLayerSession()
+		var err error
+		if d.Get("origin_volume_id").(int) > 0 {
+			_, err = buildStorageAsAServiceOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, storageProtocol, datacenter)
+		} else {
+			_, err = buildStorageProductOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, storageProtocol, datacenter)
+		}
+		if err != nil {
+			return fmt.Errorf("Invalid storage order: %s", err)
+		}
+
+		return nil
+	}
+}
+
+// validateBareMetalOrder builds the monthly bare metal server order during plan so that invalid
+// key names, memory sizes, network options and data centers are reported before anything is ordered.
+// Hourly and quote based servers are validated by SoftLayer when the order template is generated.
+func validateBareMetalOrder(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || d.Get("quote_id").(int) > 0 || d.Get("fixed_config_preset").(string) != "" {
+		return nil
+	}
+
+	// Values computed from other resources are not known until apply.
+	for _, key := range []string{"package_key_name", "process_key_name", "os_key_name", "datacenter"} {
+		if d.Get(key).(string) == "" {
+			return nil
+		}
+	}
+	if d.Get("memory").(int) == 0 {
+		return nil
+	}
+
+	_, err := getMonthlyBareMetalOrder(d, meta)
+	if err != nil {
+		return fmt.Errorf("Invalid bare metal server order: %s", err)
+	}
+
+	return nil
+}
+
+// validateNetworkGatewayOrder builds the order of the gateway members during plan, like
+// validateBareMetalOrder does for monthly bare metal servers.
+func validateNetworkGatewayOrder(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	// Values computed from other resources are not known until apply.
+	for _, key := range []string{"package_key_name", "process_key_name", "os_key_name", "datacenter"} {
+		if d.Get(key).(string) == "" {
+			return nil
+		}
+	}
+	if d.Get("memory").(int) == 0 {
+		return nil
+	}
+
+	_, err := getNetworkGatewayOrder(d, meta)
+	if err != nil {
+		return fmt.Errorf("Invalid network gateway order: %s", err)
+	}
+
+	return nil
+}
+
+func validateStorageModification(d *schema.ResourceDiff, meta interface{}) error {
+	oldCapacity, newCapacity := d.GetChange("capacity")
+	iops := d.Get("iops").(float64)
+
+	// Values computed from other resources are not known until apply.
+	if newCapacity.(int) == 0 || iops == 0 {
+		return nil
+	}
+
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	_, err := buildStorageUpgradeOrderContainer(sess, d.Get("type").(string), iops, newCapacity.(int), oldCapacity.(int))
+	if err != nil {
+		return fmt.Errorf("Invalid storage modification: %s", err)
+	}
+
+	return nil
+}
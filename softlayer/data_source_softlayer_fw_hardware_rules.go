@@ -0,0 +1,158 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	fwTypeDedicated = "dedicated"
+	fwTypeHardware  = "hardware"
+)
+
+func dataSourceSoftLayerFwHardwareRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerFwHardwareRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"firewall_id": {
+				Description: "The ID of the dedicated or per-server hardware firewall",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+
+			"firewall_type": {
+				Description: "The type of the firewall, either dedicated or hardware",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     fwTypeDedicated,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					fwType := v.(string)
+					if fwType != fwTypeDedicated && fwType != fwTypeHardware {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either '%s' or '%s'", k, fwTypeDedicated, fwTypeHardware))
+					}
+					return
+				},
+			},
+
+			"interface": {
+				Description: "The interface of a dedicated firewall, either outside or inside",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     fwInterfaceOutside,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					fwInterface := v.(string)
+					if fwInterface != fwInterfaceOutside && fwInterface != fwInterfaceInside {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either '%s' or '%s'", k, fwInterfaceOutside, fwInterfaceInside))
+					}
+					return
+				},
+			},
+
+			"access_control_list_id": {
+				Description: "The ID of the access control list of a dedicated firewall",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"rules": {
+				Description: "The rules of the firewall, IPv4 rules first, in the order they are applied",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"order_value": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"src_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"src_ip_cidr": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"dst_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dst_ip_cidr": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"dst_port_range_start": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"dst_port_range_end": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"notes": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerFwHardwareRulesRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId := d.Get("firewall_id").(int)
+
+	var fwRules []datatypes.Network_Vlan_Firewall_Rule
+	var err error
+	if d.Get("firewall_type").(string) == fwTypeHardware {
+		fwRules, err = getHardwareFirewallRules(sess, fwId)()
+		if err != nil {
+			return fmt.Errorf("Error retrieving rules of firewall %d: %s", fwId, err)
+		}
+		d.SetId(strconv.Itoa(fwId))
+	} else {
+		var fwContextACLId int
+		fwContextACLId, err = getFirewallContextAccessControlListId(fwId, d.Get("interface").(string), sess)
+		if err != nil {
+			return fmt.Errorf("Error retrieving rules of firewall %d: %s", fwId, err)
+		}
+		fwRules, err = getAccessControlListRules(sess, fwContextACLId)()
+		if err != nil {
+			return fmt.Errorf("Error retrieving rules of firewall %d: %s", fwId, err)
+		}
+		d.SetId(strconv.Itoa(fwContextACLId))
+		d.Set("access_control_list_id", fwContextACLId)
+	}
+
+	// All rules are returned, including any-open rules, so that the rules can be compared with the device.
+	rules := make([]map[string]interface{}, 0, len(fwRules))
+	for _, rule := range sortFirewallRules(fwRules) {
+		r := flattenFirewallRule(rule)
+		r["order_value"] = sl.Get(rule.OrderValue, 0)
+		rules = append(rules, r)
+	}
+	d.Set("rules", rules)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerFwHardwareRulesDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerFwHardwareRulesDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources("data.softlayer_fw_hardware_rules.acl", "firewall_id",
+						"softlayer_fw_hardware.accfw4", "id"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_fw_hardware_rules.acl", "rules.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_fw_hardware_rules.acl", "rules.0.order_value", "1"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_fw_hardware_rules.acl", "rules.0.protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_fw_hardware_rules.acl", "rules.0.notes", "Allow SSH"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_fw_hardware_rules.acl", "rules.1.order_value", "2"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_fw_hardware_rules.acl", "rules.1.protocol", "icmp"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerFwHardwareRulesDataSourceConfig_basic = testAccCheckSoftLayerFwHardwareRules_basic + `
+data "softlayer_fw_hardware_rules" "acl" {
+    firewall_id = "${softlayer_fw_hardware_rules.rules.firewall_id}"
+    firewall_type = "hardware"
+}
+`
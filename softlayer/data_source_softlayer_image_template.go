@@ -2,23 +2,136 @@ package softlayer
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/filter"
 	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	imageTemplateVisibilityPrivate = "private"
+	imageTemplateVisibilityShared  = "shared"
+	imageTemplateVisibilityPublic  = "public"
+
+	imageTemplateDataSourceMask = "id,name,note,accountId,globalIdentifier,createDate,blockDevicesDiskSpaceTotal," +
+		"datacenters[name],tagReferences[tag[name]]," +
+		"children[blockDevices[diskImage[softwareReferences[softwareDescription[referenceCode]]]]]"
 )
 
 func dataSourceSoftLayerImageTemplate() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceSoftLayerImageTemplateRead,
 
-		// TODO: based on need add properties for visibility, type of image,
-		// notes, size, shared on accounts if needed
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The name of this image template",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"name_regex": {
+				Description: "A regular expression which the name of the image template must match",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := regexp.Compile(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf(
+							"%q contains an invalid regular expression: %s", k, err))
+					}
+					return
+				},
+			},
+
+			"visibility": {
+				Description: "The visibility of the image template: private, shared or public",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					visibility := v.(string)
+					if visibility != imageTemplateVisibilityPrivate &&
+						visibility != imageTemplateVisibilityShared &&
+						visibility != imageTemplateVisibilityPublic {
+						errors = append(errors, fmt.Errorf(
+							"%q must be one of '%s', '%s' or '%s'", k,
+							imageTemplateVisibilityPrivate, imageTemplateVisibilityShared, imageTemplateVisibilityPublic))
+					}
+					return
+				},
+			},
+
+			"datacenter": {
+				Description: "A datacenter where the image template must be available",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"tag": {
+				Description: "A tag which the image template must have",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"os_reference_code": {
+				Description: "The operating system reference code of the image template",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"most_recent": {
+				Description: "If true and multiple image templates are found, the most recently created image template is used. " +
+					"If false, an error is returned, unless the image template is looked up by name only",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"note": {
+				Description: "The note of the image template",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"global_identifier": {
+				Description: "The global identifier of the image template",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"datacenters": {
+				Description: "The datacenters where the image template is available",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tags": {
+				Description: "The tags of the image template",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"size": {
+				Description: "The total disk space of the image template in bytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"create_date": {
+				Description: "The date the image template was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"account_id": {
+				Description: "The ID of the account which owns the image template",
+				Type:        schema.TypeInt,
+				Computed:    true,
 			},
 		},
 	}
@@ -29,37 +142,175 @@ func dataSourceSoftLayerImageTemplateRead(d *schema.ResourceData, meta interface
 	service := services.GetAccountService(sess)
 
 	name := d.Get("name").(string)
+	visibility := d.Get("visibility").(string)
+	mostRecent := d.Get("most_recent").(bool)
 
-	imageTemplates, err := service.
-		Mask("id,name").
-		GetBlockDeviceTemplateGroups()
-	if err != nil {
-		return fmt.Errorf("Error looking up image template [%s]: %s", name, err)
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
 	}
 
-	for _, imageTemplate := range imageTemplates {
-		if imageTemplate.Name != nil && *imageTemplate.Name == name {
-			d.SetId(fmt.Sprintf("%d", *imageTemplate.Id))
-			return nil
+	matches := func(imageTemplate datatypes.Virtual_Guest_Block_Device_Template_Group) bool {
+		if name != "" && sl.Get(imageTemplate.Name, "") != name {
+			return false
+		}
+		if nameRegex != nil && !nameRegex.MatchString(sl.Get(imageTemplate.Name, "").(string)) {
+			return false
+		}
+		if datacenter, ok := d.GetOk("datacenter"); ok && !imageTemplateHasDatacenter(imageTemplate, datacenter.(string)) {
+			return false
+		}
+		if tag, ok := d.GetOk("tag"); ok && !imageTemplateHasTag(imageTemplate, tag.(string)) {
+			return false
+		}
+		if osReferenceCode, ok := d.GetOk("os_reference_code"); ok &&
+			getImageTemplateOsReferenceCode(imageTemplate) != osReferenceCode.(string) {
+			return false
+		}
+		return true
+	}
+
+	imageTemplates := []datatypes.Virtual_Guest_Block_Device_Template_Group{}
+
+	// Private and shared images are looked up first.
+	if visibility != imageTemplateVisibilityPublic {
+		account, err := service.Mask("id").GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving account: %s", err)
+		}
+
+		accountImageTemplates, err := service.
+			Mask(imageTemplateDataSourceMask).
+			GetBlockDeviceTemplateGroups()
+		if err != nil {
+			return fmt.Errorf("Error looking up image templates: %s", err)
+		}
+
+		for _, imageTemplate := range accountImageTemplates {
+			private := imageTemplate.AccountId != nil && *imageTemplate.AccountId == *account.Id
+			if (visibility == imageTemplateVisibilityPrivate && !private) ||
+				(visibility == imageTemplateVisibilityShared && private) {
+				continue
+			}
+			if matches(imageTemplate) {
+				imageTemplates = append(imageTemplates, imageTemplate)
+			}
 		}
 	}
 
 	// Image not found among private nor shared images in the account.
 	// Looking up in the public images
-	templateService := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess)
-	pubImageTemplates, err := templateService.
-		Mask("id,name").
-		Filter(filter.Path("name").Eq(name).Build()).
-		GetPublicImages()
-	if err != nil {
-		return fmt.Errorf("Error looking up image template [%s] among the public images: %s", name, err)
+	if len(imageTemplates) == 0 && (visibility == "" || visibility == imageTemplateVisibilityPublic) {
+		templateService := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).
+			Mask(imageTemplateDataSourceMask)
+		if name != "" {
+			templateService = templateService.Filter(filter.Path("name").Eq(name).Build())
+		}
+
+		pubImageTemplates, err := templateService.GetPublicImages()
+		if err != nil {
+			return fmt.Errorf("Error looking up image templates among the public images: %s", err)
+		}
+
+		for _, imageTemplate := range pubImageTemplates {
+			if matches(imageTemplate) {
+				imageTemplates = append(imageTemplates, imageTemplate)
+			}
+		}
+	}
+
+	if len(imageTemplates) == 0 {
+		return fmt.Errorf("Could not find image template matching the given criteria")
+	}
+
+	// A lookup by name alone keeps using the first image template with the name.
+	nameOnly := true
+	for _, criterion := range []string{"name_regex", "visibility", "datacenter", "tag", "os_reference_code"} {
+		if _, ok := d.GetOk(criterion); ok {
+			nameOnly = false
+		}
 	}
 
-	if len(pubImageTemplates) > 0 {
-		imageTemplate := pubImageTemplates[0]
-		d.SetId(fmt.Sprintf("%d", *imageTemplate.Id))
-		return nil
+	if len(imageTemplates) > 1 && !mostRecent && !nameOnly {
+		return fmt.Errorf(
+			"More than one image template found matching the given criteria. " +
+				"Either set 'most_recent' to true in your " +
+				"configuration to force the most recent image template " +
+				"to be used, or use more specific criteria")
 	}
 
-	return fmt.Errorf("Could not find image template with name [%s]", name)
+	imageTemplate := imageTemplates[0]
+	if mostRecent {
+		// find image template with most recent create date
+		for _, t := range imageTemplates[1:] {
+			if t.CreateDate != nil && (imageTemplate.CreateDate == nil || t.CreateDate.After(imageTemplate.CreateDate.Time)) {
+				imageTemplate = t
+			}
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d", *imageTemplate.Id))
+	d.Set("name", sl.Get(imageTemplate.Name, nil))
+	d.Set("note", sl.Get(imageTemplate.Note, nil))
+	d.Set("global_identifier", sl.Get(imageTemplate.GlobalIdentifier, nil))
+	d.Set("account_id", sl.Get(imageTemplate.AccountId, nil))
+	d.Set("os_reference_code", getImageTemplateOsReferenceCode(imageTemplate))
+
+	if imageTemplate.BlockDevicesDiskSpaceTotal != nil {
+		d.Set("size", int(*imageTemplate.BlockDevicesDiskSpaceTotal))
+	}
+	if imageTemplate.CreateDate != nil {
+		d.Set("create_date", imageTemplate.CreateDate.String())
+	}
+
+	datacenters := make([]string, 0, len(imageTemplate.Datacenters))
+	for _, datacenter := range imageTemplate.Datacenters {
+		datacenters = append(datacenters, *datacenter.Name)
+	}
+	d.Set("datacenters", datacenters)
+
+	tags := make([]string, 0, len(imageTemplate.TagReferences))
+	for _, tagReference := range imageTemplate.TagReferences {
+		if tagReference.Tag != nil && tagReference.Tag.Name != nil {
+			tags = append(tags, *tagReference.Tag.Name)
+		}
+	}
+	d.Set("tags", tags)
+
+	return nil
+}
+
+func imageTemplateHasDatacenter(imageTemplate datatypes.Virtual_Guest_Block_Device_Template_Group, name string) bool {
+	for _, datacenter := range imageTemplate.Datacenters {
+		if datacenter.Name != nil && *datacenter.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func imageTemplateHasTag(imageTemplate datatypes.Virtual_Guest_Block_Device_Template_Group, name string) bool {
+	for _, tagReference := range imageTemplate.TagReferences {
+		if tagReference.Tag != nil && tagReference.Tag.Name != nil && *tagReference.Tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the operating system reference code of the first disk image of the image template which has one.
+func getImageTemplateOsReferenceCode(imageTemplate datatypes.Virtual_Guest_Block_Device_Template_Group) string {
+	for _, child := range imageTemplate.Children {
+		for _, blockDevice := range child.BlockDevices {
+			if blockDevice.DiskImage == nil {
+				continue
+			}
+			for _, software := range blockDevice.DiskImage.SoftwareReferences {
+				if software.SoftwareDescription != nil && software.SoftwareDescription.ReferenceCode != nil {
+					return *software.SoftwareDescription.ReferenceCode
+				}
+			}
+		}
+	}
+	return ""
 }
@@ -44,6 +44,27 @@ func TestAccSoftLayerImageTemplateDataSource_Basic(t *testing.T) {
 					),
 				),
 			},
+			// Tests looking up the most recent public image by name regex and OS
+			{
+				Config: testAccCheckSoftLayerImageTemplateDataSourceConfig_mostRecent,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.softlayer_image_template.tfacc_img_tmpl",
+						"name",
+						regexp.MustCompile("^25GB - Ubuntu"),
+					),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_image_template.tfacc_img_tmpl",
+						"os_reference_code",
+						"UBUNTU_16_64",
+					),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_image_template.tfacc_img_tmpl",
+						"global_identifier",
+						regexp.MustCompile("^[0-9a-f-]+$"),
+					),
+				),
+			},
 		},
 	})
 }
@@ -59,3 +80,12 @@ data "softlayer_image_template" "tfacc_img_tmpl" {
     name = "RightImage_Ubuntu_12.04_amd64_v13.5"
 }
 `
+
+const testAccCheckSoftLayerImageTemplateDataSourceConfig_mostRecent = `
+data "softlayer_image_template" "tfacc_img_tmpl" {
+    name_regex = "^25GB - Ubuntu"
+    visibility = "public"
+    os_reference_code = "UBUNTU_16_64"
+    most_recent = true
+}
+`
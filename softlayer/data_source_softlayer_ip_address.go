@@ -0,0 +1,148 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const ipAddressDataSourceMask = "id,ipAddress,note,subnetId,isNetwork,isGateway,isBroadcast,isReserved," +
+	"subnet[networkIdentifier,cidr],virtualGuest[id],hardware[id]"
+
+func dataSourceSoftLayerIpAddress() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerIpAddressRead,
+
+		Schema: map[string]*schema.Schema{
+			"ip_address": {
+				Description: "The IP address",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"subnet_id": {
+				Description: "The ID of the subnet of the IP address",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"notes": {
+				Description: "The notes of the IP address",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"subnet_cidr": {
+				Description: "The network identifier and CIDR of the subnet of the IP address",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"is_network": {
+				Description: "Whether the IP address is the network address of its subnet",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"is_gateway": {
+				Description: "Whether the IP address is the gateway address of its subnet",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"is_broadcast": {
+				Description: "Whether the IP address is the broadcast address of its subnet",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"is_reserved": {
+				Description: "Whether the IP address is reserved",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"virtual_guest_id": {
+				Description: "The ID of the virtual guest the IP address is assigned to",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"hardware_id": {
+				Description: "The ID of the hardware the IP address is assigned to",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerIpAddressRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	address := d.Get("ip_address").(string)
+	subnetId := d.Get("subnet_id").(int)
+	notes := d.Get("notes").(string)
+
+	var ipAddress datatypes.Network_Subnet_IpAddress
+	if address != "" {
+		// Got an address, get the IP address and compute the subnet
+		var err error
+		ipAddress, err = services.GetNetworkSubnetIpAddressService(sess).
+			Mask(ipAddressDataSourceMask).
+			GetByIpAddress(sl.String(address))
+		if err != nil {
+			return fmt.Errorf("Error retrieving IP address %s: %s", address, err)
+		}
+		if ipAddress.Id == nil {
+			return fmt.Errorf("No IP address was found with the address '%s'", address)
+		}
+	} else if subnetId != 0 && notes != "" {
+		// Got a subnet and notes, look up the IP address within the subnet
+		ipAddresses, err := services.GetNetworkSubnetService(sess).
+			Id(subnetId).
+			Mask(ipAddressDataSourceMask).
+			Filter(filter.Path("ipAddresses.note").Eq(notes).Build()).
+			GetIpAddresses()
+		if err != nil {
+			return fmt.Errorf("Error retrieving IP addresses of subnet %d: %s", subnetId, err)
+		}
+		if len(ipAddresses) == 0 {
+			return fmt.Errorf("No IP address was found with the notes '%s' in subnet %d", notes, subnetId)
+		}
+		if len(ipAddresses) > 1 {
+			return fmt.Errorf("%d IP addresses were found with the notes '%s' in subnet %d", len(ipAddresses), notes, subnetId)
+		}
+		ipAddress = ipAddresses[0]
+	} else {
+		return errors.New("Missing required properties. Need an IP address, or the subnet ID and notes of the IP address.")
+	}
+
+	d.SetId(fmt.Sprintf("%d", *ipAddress.Id))
+	d.Set("ip_address", sl.Get(ipAddress.IpAddress, nil))
+	d.Set("subnet_id", sl.Get(ipAddress.SubnetId, nil))
+	d.Set("notes", sl.Get(ipAddress.Note, nil))
+	if ipAddress.Subnet != nil && ipAddress.Subnet.NetworkIdentifier != nil && ipAddress.Subnet.Cidr != nil {
+		d.Set("subnet_cidr", fmt.Sprintf("%s/%d", *ipAddress.Subnet.NetworkIdentifier, *ipAddress.Subnet.Cidr))
+	}
+	d.Set("is_network", sl.Get(ipAddress.IsNetwork, false))
+	d.Set("is_gateway", sl.Get(ipAddress.IsGateway, false))
+	d.Set("is_broadcast", sl.Get(ipAddress.IsBroadcast, false))
+	d.Set("is_reserved", sl.Get(ipAddress.IsReserved, false))
+	if ipAddress.VirtualGuest != nil {
+		d.Set("virtual_guest_id", sl.Get(ipAddress.VirtualGuest.Id, nil))
+	}
+	if ipAddress.Hardware != nil {
+		d.Set("hardware_id", sl.Get(ipAddress.Hardware.Id, nil))
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func dataSourceSoftLayerNetworkStorage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerNetworkStorageRead,
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"volumename": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"iops": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mountpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"snapshot_capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"os_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"allowed_virtual_guest_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"allowed_virtual_guest_info": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceSoftLayerNetworkStorageAllowedHost(),
+			},
+
+			"allowed_hardware_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"allowed_hardware_info": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceSoftLayerNetworkStorageAllowedHost(),
+			},
+
+			"allowed_subnets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"allowed_ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerNetworkStorageAllowedHost() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"host_iqn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerNetworkStorageRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetAccountService(sess)
+
+	volumeId := d.Get("volume_id").(int)
+	volumename := d.Get("volumename").(string)
+	notes := d.Get("notes").(string)
+	datacenter := d.Get("datacenter").(string)
+
+	if volumeId == 0 && volumename == "" && notes == "" && datacenter == "" {
+		return errors.New("Missing required properties. Need a volume ID, volume name, notes or datacenter.")
+	}
+
+	// Only block (ISCSI) and file (NAS) storage volumes are looked up.
+	filters := []filter.Filter{
+		filter.Path("networkStorage.nasType").In("ISCSI", "NAS"),
+	}
+	if volumeId != 0 {
+		filters = append(filters, filter.Path("networkStorage.id").Eq(volumeId))
+	}
+	if volumename != "" {
+		filters = append(filters, filter.Path("networkStorage.username").Eq(volumename))
+	}
+	if notes != "" {
+		filters = append(filters, filter.Path("networkStorage.notes").Eq(notes))
+	}
+
+	storages, err := service.
+		Mask(storageDetailMask + ",nasType").
+		Filter(filter.Build(filters...)).
+		GetNetworkStorage()
+	if err != nil {
+		return fmt.Errorf("Error looking up storage: %s", err)
+	}
+
+	// Parse data center short name from ServiceResourceName in the same way as the storage resources.
+	r, _ := regexp.Compile("[a-zA-Z]{3}[0-9]{2}")
+	matches := make([]datatypes.Network_Storage, 0, len(storages))
+	for _, storage := range storages {
+		if datacenter != "" && (storage.ServiceResourceName == nil || r.FindString(*storage.ServiceResourceName) != datacenter) {
+			continue
+		}
+		matches = append(matches, storage)
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("No storage was found with the given arguments")
+	}
+	if len(matches) > 1 {
+		return fmt.Errorf("%d storage volumes were found with the given arguments. Add arguments to select a single volume.", len(matches))
+	}
+
+	storage := matches[0]
+	d.SetId(fmt.Sprintf("%d", *storage.Id))
+	d.Set("volume_id", *storage.Id)
+	d.Set("volumename", *storage.Username)
+
+	if storage.StorageType != nil && storage.StorageType.Description != nil {
+		storageType := strings.Fields(*storage.StorageType.Description)[0]
+		iops, err := getIops(storage, storageType)
+		if err != nil {
+			return fmt.Errorf("Error retrieving storage information: %s", err)
+		}
+		d.Set("type", storageType)
+		d.Set("iops", iops)
+	}
+
+	if storage.CapacityGb != nil {
+		d.Set("capacity", *storage.CapacityGb)
+	}
+	if storage.ServiceResourceBackendIpAddress != nil {
+		d.Set("hostname", *storage.ServiceResourceBackendIpAddress)
+	}
+	if storage.ServiceResourceName != nil {
+		d.Set("datacenter", r.FindString(*storage.ServiceResourceName))
+	}
+	if storage.SnapshotCapacityGb != nil {
+		snapshotCapacity, _ := strconv.Atoi(*storage.SnapshotCapacityGb)
+		d.Set("snapshot_capacity", snapshotCapacity)
+	}
+	if storage.OsType != nil {
+		d.Set("os_type", *storage.OsType.Name)
+	}
+	if storage.Notes != nil {
+		d.Set("notes", *storage.Notes)
+	}
+
+	allowedIpAddresses := make([]string, 0, len(storage.AllowedIpAddresses))
+	for _, allowedIpAddress := range storage.AllowedIpAddresses {
+		allowedIpAddresses = append(allowedIpAddresses, *allowedIpAddress.IpAddress)
+	}
+	d.Set("allowed_ip_addresses", allowedIpAddresses)
+
+	allowedSubnets := make([]string, 0, len(storage.AllowedSubnets))
+	for _, allowedSubnet := range storage.AllowedSubnets {
+		allowedSubnets = append(allowedSubnets, *allowedSubnet.NetworkIdentifier+"/"+strconv.Itoa(*allowedSubnet.Cidr))
+	}
+	d.Set("allowed_subnets", allowedSubnets)
+
+	allowedVirtualGuestIds := make([]int, 0, len(storage.AllowedVirtualGuests))
+	allowedVirtualGuestInfo := make([]map[string]interface{}, 0, len(storage.AllowedVirtualGuests))
+	for _, allowedVirtualGuest := range storage.AllowedVirtualGuests {
+		info := flattenStorageAllowedHost(allowedVirtualGuest.AllowedHost)
+		info["id"] = *allowedVirtualGuest.Id
+		allowedVirtualGuestInfo = append(allowedVirtualGuestInfo, info)
+		allowedVirtualGuestIds = append(allowedVirtualGuestIds, *allowedVirtualGuest.Id)
+	}
+	d.Set("allowed_virtual_guest_ids", allowedVirtualGuestIds)
+	d.Set("allowed_virtual_guest_info", allowedVirtualGuestInfo)
+
+	allowedHardwareIds := make([]int, 0, len(storage.AllowedHardware))
+	allowedHardwareInfo := make([]map[string]interface{}, 0, len(storage.AllowedHardware))
+	for _, allowedHardware := range storage.AllowedHardware {
+		info := flattenStorageAllowedHost(allowedHardware.AllowedHost)
+		info["id"] = *allowedHardware.Id
+		allowedHardwareInfo = append(allowedHardwareInfo, info)
+		allowedHardwareIds = append(allowedHardwareIds, *allowedHardware.Id)
+	}
+	d.Set("allowed_hardware_ids", allowedHardwareIds)
+	d.Set("allowed_hardware_info", allowedHardwareInfo)
+
+	// The mount address is only available for file storage.
+	if storage.NasType != nil && *storage.NasType == "NAS" {
+		mountpoint, err := services.GetNetworkStorageService(sess).Id(*storage.Id).GetFileNetworkMountAddress()
+		if err != nil {
+			return fmt.Errorf("Error retrieving storage information: %s", err)
+		}
+		d.Set("mountpoint", mountpoint)
+	}
+
+	return nil
+}
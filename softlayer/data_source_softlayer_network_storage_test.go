@@ -0,0 +1,55 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerNetworkStorageDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerNetworkStorageDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.softlayer_network_storage.storage", "id", regexp.MustCompile("^[0-9]+$")),
+					testAccCheckSoftLayerResources("data.softlayer_network_storage.storage", "volume_id",
+						"softlayer_file_storage.fs_endurance", "id"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_network_storage.storage", "type", "Endurance"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_network_storage.storage", "capacity", "20"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_network_storage.storage", "iops", "0.25"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_network_storage.storage", "datacenter", "dal06"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_network_storage.storage", "notes", "shared volume"),
+					resource.TestCheckResourceAttrSet(
+						"data.softlayer_network_storage.storage", "hostname"),
+					resource.TestCheckResourceAttrSet(
+						"data.softlayer_network_storage.storage", "mountpoint"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerNetworkStorageDataSourceConfig_basic = `
+resource "softlayer_file_storage" "fs_endurance" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 0.25
+        notes = "shared volume"
+}
+
+data "softlayer_network_storage" "storage" {
+        volumename = "${softlayer_file_storage.fs_endurance.volumename}"
+        datacenter = "dal06"
+}
+`
@@ -0,0 +1,195 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const subnetDataSourceMask = "id,note,cidr,gateway,broadcastAddress,networkIdentifier,netmask,version,subnetType," +
+	"addressSpace,totalIpAddresses,networkVlanId,datacenter[name]," +
+	"ipAddresses[ipAddress,isNetwork,isGateway,isBroadcast]"
+
+func dataSourceSoftLayerSubnet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerSubnetRead,
+
+		Schema: map[string]*schema.Schema{
+			"subnet_cidr": {
+				Description: "The network identifier and CIDR of the subnet, e.g. 10.0.0.0/29",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"vlan_id": {
+				Description: "The ID of the VLAN of the subnet",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"datacenter": {
+				Description: "The datacenter of the subnet",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"notes": {
+				Description: "The notes of the subnet",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"ip_address": {
+				Description: "An IP address which belongs to the subnet",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"network_identifier": {
+				Description: "The network identifier of the subnet",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"cidr": {
+				Description: "The prefix length of the subnet",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"netmask": {
+				Description: "The netmask of the subnet",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"gateway_ip": {
+				Description: "The gateway IP address of the subnet",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"broadcast_ip": {
+				Description: "The broadcast IP address of the subnet",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"type": {
+				Description: "The type of the subnet, e.g. PRIMARY, SECONDARY_ON_VLAN or STATIC_IP_ROUTED",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"private": {
+				Description: "Whether the subnet is private",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"ip_version": {
+				Description: "The IP version of the subnet",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"total_ip_addresses": {
+				Description: "The number of IP addresses of the subnet",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"usable_ip_addresses": {
+				Description: "The IP addresses of the subnet except the network, gateway and broadcast addresses",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetAccountService(sess)
+
+	filters := []filter.Filter{}
+	if subnetCidr, ok := d.GetOk("subnet_cidr"); ok {
+		parts := strings.Split(subnetCidr.(string), "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("Invalid subnet CIDR, must be <network identifier>/<cidr>: %s", subnetCidr.(string))
+		}
+		cidr, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return fmt.Errorf("Invalid subnet CIDR, must be <network identifier>/<cidr>: %s", subnetCidr.(string))
+		}
+		filters = append(filters,
+			filter.Path("subnets.networkIdentifier").Eq(parts[0]),
+			filter.Path("subnets.cidr").Eq(cidr))
+	}
+	if vlanId, ok := d.GetOk("vlan_id"); ok {
+		filters = append(filters, filter.Path("subnets.networkVlanId").Eq(vlanId.(int)))
+	}
+	if datacenter, ok := d.GetOk("datacenter"); ok {
+		filters = append(filters, filter.Path("subnets.datacenter.name").Eq(datacenter.(string)))
+	}
+	if notes, ok := d.GetOk("notes"); ok {
+		filters = append(filters, filter.Path("subnets.note").Eq(notes.(string)))
+	}
+	if ipAddress, ok := d.GetOk("ip_address"); ok {
+		filters = append(filters, filter.Path("subnets.ipAddresses.ipAddress").Eq(ipAddress.(string)))
+	}
+
+	if len(filters) == 0 {
+		return errors.New("Missing required properties. Need a subnet CIDR, VLAN ID, datacenter, notes or IP address.")
+	}
+
+	subnets, err := service.
+		Mask(subnetDataSourceMask).
+		Filter(filter.Build(filters...)).
+		GetSubnets()
+	if err != nil {
+		return fmt.Errorf("Error retrieving subnets: %s", err)
+	}
+
+	if len(subnets) == 0 {
+		return errors.New("No subnet was found matching the given criteria")
+	}
+	if len(subnets) > 1 {
+		return fmt.Errorf("%d subnets were found matching the given criteria. Use more specific criteria", len(subnets))
+	}
+
+	subnet := subnets[0]
+	d.SetId(fmt.Sprintf("%d", *subnet.Id))
+	d.Set("subnet_cidr", fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr))
+	d.Set("vlan_id", sl.Get(subnet.NetworkVlanId, nil))
+	d.Set("notes", sl.Get(subnet.Note, nil))
+	if subnet.Datacenter != nil {
+		d.Set("datacenter", sl.Get(subnet.Datacenter.Name, nil))
+	}
+
+	d.Set("network_identifier", *subnet.NetworkIdentifier)
+	d.Set("cidr", *subnet.Cidr)
+	d.Set("netmask", sl.Get(subnet.Netmask, nil))
+	d.Set("gateway_ip", sl.Get(subnet.Gateway, nil))
+	d.Set("broadcast_ip", sl.Get(subnet.BroadcastAddress, nil))
+	d.Set("type", sl.Get(subnet.SubnetType, nil))
+	d.Set("private", sl.Get(subnet.AddressSpace, "") == "PRIVATE")
+	d.Set("ip_version", sl.Get(subnet.Version, nil))
+	if subnet.TotalIpAddresses != nil {
+		d.Set("total_ip_addresses", int(*subnet.TotalIpAddresses))
+	}
+	d.Set("usable_ip_addresses", getUsableIpAddresses(subnet.IpAddresses))
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerSubnetDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerSubnetDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources("data.softlayer_subnet.portable", "id",
+						"softlayer_subnet.portable_subnet", "id"),
+					testAccCheckSoftLayerResources("data.softlayer_subnet.portable", "gateway_ip",
+						"softlayer_subnet.portable_subnet", "gateway_ip"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.portable", "cidr", "29"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.portable", "private", "true"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.portable", "type", "SECONDARY_ON_VLAN"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.portable", "usable_ip_addresses.#", "5"),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_subnet.portable", "broadcast_ip", regexp.MustCompile("^[0-9.]+$")),
+
+					testAccCheckSoftLayerResources("data.softlayer_subnet.by_cidr", "id",
+						"softlayer_subnet.portable_subnet", "id"),
+
+					testAccCheckSoftLayerResources("data.softlayer_ip_address.gateway", "ip_address",
+						"softlayer_subnet.portable_subnet", "gateway_ip"),
+					testAccCheckSoftLayerResources("data.softlayer_ip_address.gateway", "subnet_id",
+						"softlayer_subnet.portable_subnet", "id"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_ip_address.gateway", "is_gateway", "true"),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_ip_address.gateway", "id", regexp.MustCompile("^[0-9]+$")),
+
+					testAccCheckSoftLayerResources("data.softlayer_ip_address.guest", "virtual_guest_id",
+						"softlayer_virtual_guest.subnetvm1", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerSubnetDataSourceConfig_basic = testAccCheckSoftLayerSubnetConfig_guest + `
+resource "softlayer_subnet" "portable_subnet" {
+    type = "PORTABLE"
+    private = true
+    ip_version = 4
+    capacity = 8
+    vlan_id = "${softlayer_virtual_guest.subnetvm1.private_vlan_id}"
+    notes = "subnet_data_source_test"
+}
+
+data "softlayer_subnet" "portable" {
+    notes = "${softlayer_subnet.portable_subnet.notes}"
+    vlan_id = "${softlayer_subnet.portable_subnet.vlan_id}"
+}
+
+data "softlayer_subnet" "by_cidr" {
+    subnet_cidr = "${softlayer_subnet.portable_subnet.subnet_cidr}"
+}
+
+data "softlayer_ip_address" "gateway" {
+    ip_address = "${softlayer_subnet.portable_subnet.gateway_ip}"
+}
+
+data "softlayer_ip_address" "guest" {
+    ip_address = "${softlayer_virtual_guest.subnetvm1.ipv4_address}"
+}
+`
@@ -0,0 +1,314 @@
+package softlayer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+const swiftListLimit = 10000
+
+// swiftClient is a minimal client of the Swift API of SoftLayer object storage. It authenticates with the
+// v1.0 auth API and keeps the storage URL and the token for later requests.
+type swiftClient struct {
+	storageUrl string
+	authToken  string
+	httpClient *http.Client
+}
+
+// swiftError is returned when the Swift API responds with an unexpected status code.
+type swiftError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	Path       string
+}
+
+func (e swiftError) Error() string {
+	return fmt.Sprintf("%s %s failed: %s", e.Method, e.Path, e.Status)
+}
+
+func isSwiftNotFound(err error) bool {
+	if swiftErr, ok := err.(swiftError); ok && swiftErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return false
+}
+
+func newSwiftClient(authUrl string, username string, apiKey string, timeout time.Duration) (*swiftClient, error) {
+	httpClient := &http.Client{Timeout: timeout}
+
+	req, err := http.NewRequest("GET", authUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Auth-User", username)
+	req.Header.Set("X-Auth-Key", apiKey)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error authenticating to %s: %s", authUrl, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("Error authenticating to %s: %s", authUrl, resp.Status)
+	}
+
+	client := &swiftClient{
+		storageUrl: strings.TrimRight(resp.Header.Get("X-Storage-Url"), "/"),
+		authToken:  resp.Header.Get("X-Auth-Token"),
+		httpClient: httpClient,
+	}
+	if client.storageUrl == "" || client.authToken == "" {
+		return nil, fmt.Errorf("Error authenticating to %s: no storage URL or token returned", authUrl)
+	}
+
+	return client, nil
+}
+
+// Returns a Swift client of the object storage account in the datacenter. The auth endpoint is looked up in
+// the connection information of the account.
+func getObjectStorageSwiftClient(meta interface{}, accountName string, datacenter string) (*swiftClient, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	account, err := getObjectStorageAccount(sess, accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	storageService := services.GetNetworkStorageService(sess).Id(*account.Id)
+
+	connections, err := storageService.GetObjectStorageConnectionInformation()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving endpoints of object storage account %s: %s", accountName, err)
+	}
+
+	authUrl := ""
+	datacenters := make([]string, 0, len(connections))
+	for _, connection := range connections {
+		if connection.DatacenterShortName == nil || connection.PublicEndpoint == nil {
+			continue
+		}
+		datacenters = append(datacenters, *connection.DatacenterShortName)
+		if *connection.DatacenterShortName == datacenter {
+			authUrl = *connection.PublicEndpoint
+		}
+	}
+	if authUrl == "" {
+		return nil, fmt.Errorf("Object storage is not available in %s. Available datacenters are %s",
+			datacenter, strings.Join(datacenters, ", "))
+	}
+	if !strings.Contains(authUrl, "/auth/") {
+		authUrl = strings.TrimRight(authUrl, "/") + "/auth/v1.0"
+	}
+
+	username, apiKey, err := getObjectStorageCredentials(sess, accountName, *account.Id)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		return nil, fmt.Errorf("Object storage account %s has no Swift credentials", accountName)
+	}
+
+	return newSwiftClient(authUrl, username, apiKey, sess.Timeout)
+}
+
+// Returns the Swift username and API key of the object storage account, or empty strings if the account has no
+// credentials. The SoftLayer API key of the session is never used, so that it isn't kept in the state.
+func getObjectStorageCredentials(sess *session.Session, accountName string, storageId int) (string, string, error) {
+	credentials, err := services.GetNetworkStorageService(sess).
+		Id(storageId).
+		Mask("username,password").
+		GetCredentials()
+	if err != nil {
+		return "", "", fmt.Errorf("Error retrieving credentials of object storage account %s: %s", accountName, err)
+	}
+
+	for _, credential := range credentials {
+		if credential.Username != nil && credential.Password != nil {
+			username := *credential.Username
+			if !strings.Contains(username, ":") {
+				username = accountName + ":" + username
+			}
+			return username, *credential.Password, nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// Escapes each segment of a Swift path. Object names keep their slashes.
+func swiftPath(container string, object ...string) string {
+	path := "/" + url.PathEscape(container)
+	for _, o := range object {
+		segments := strings.Split(o, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		path += "/" + strings.Join(segments, "/")
+	}
+	return path
+}
+
+func (c *swiftClient) do(method string, path string, headers map[string]string, body io.Reader, expected ...int) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.storageUrl+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Auth-Token", c.authToken)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return nil, swiftError{StatusCode: resp.StatusCode, Status: resp.Status, Method: method, Path: path}
+}
+
+func (c *swiftClient) PutContainer(container string, headers map[string]string) error {
+	resp, err := c.do("PUT", swiftPath(container), headers, nil, http.StatusCreated, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *swiftClient) PostContainer(container string, headers map[string]string) error {
+	resp, err := c.do("POST", swiftPath(container), headers, nil, http.StatusNoContent, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *swiftClient) HeadContainer(container string) (http.Header, error) {
+	resp, err := c.do("HEAD", swiftPath(container), nil, nil, http.StatusNoContent, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp.Header, nil
+}
+
+func (c *swiftClient) DeleteContainer(container string) error {
+	resp, err := c.do("DELETE", swiftPath(container), nil, nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Lists the names of all objects in the container.
+func (c *swiftClient) ListObjects(container string) ([]string, error) {
+	names := []string{}
+	marker := ""
+	for {
+		query := fmt.Sprintf("?limit=%d&marker=%s", swiftListLimit, url.QueryEscape(marker))
+		resp, err := c.do("GET", swiftPath(container)+query, map[string]string{"Accept": "text/plain"}, nil,
+			http.StatusOK, http.StatusNoContent)
+		if err != nil {
+			return nil, err
+		}
+
+		count := 0
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if name := scanner.Text(); name != "" {
+				names = append(names, name)
+				marker = name
+				count++
+			}
+		}
+		resp.Body.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		if count < swiftListLimit {
+			return names, nil
+		}
+	}
+}
+
+// Uploads the object and returns its ETag.
+func (c *swiftClient) PutObject(container string, object string, body io.Reader, headers map[string]string) (string, error) {
+	resp, err := c.do("PUT", swiftPath(container, object), headers, body, http.StatusCreated)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return strings.Trim(resp.Header.Get("Etag"), "\""), nil
+}
+
+// Replaces the metadata of the object.
+func (c *swiftClient) PostObject(container string, object string, headers map[string]string) error {
+	resp, err := c.do("POST", swiftPath(container, object), headers, nil, http.StatusAccepted, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *swiftClient) HeadObject(container string, object string) (http.Header, error) {
+	resp, err := c.do("HEAD", swiftPath(container, object), nil, nil, http.StatusOK, http.StatusNoContent)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp.Header, nil
+}
+
+func (c *swiftClient) DeleteObject(container string, object string) error {
+	resp, err := c.do("DELETE", swiftPath(container, object), nil, nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Returns the metadata with the given header prefix, for example X-Container-Meta-, keyed by the lower case name.
+func swiftMetadata(headers http.Header, prefix string) map[string]string {
+	metadata := map[string]string{}
+	for k, v := range headers {
+		if len(v) > 0 && strings.HasPrefix(strings.ToLower(k), strings.ToLower(prefix)) {
+			metadata[strings.ToLower(k[len(prefix):])] = v[0]
+		}
+	}
+	return metadata
+}
+
+// Validates that the keys of a metadata map are lower case. Swift returns metadata keys in lower
+// case, so other keys would always differ from the state.
+func validateSwiftMetadata(v interface{}, k string) (ws []string, errors []error) {
+	for key := range v.(map[string]interface{}) {
+		if key != strings.ToLower(key) {
+			errors = append(errors, fmt.Errorf("%s key %q must be lower case", k, key))
+		}
+	}
+	return
+}
@@ -0,0 +1,309 @@
+package softlayer
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// swiftStandIn is an in-memory stand-in of the Swift API which implements the requests used by swiftClient.
+type swiftStandIn struct {
+	sync.Mutex
+	containers map[string]http.Header
+	objects    map[string]map[string][]byte
+	headers    map[string]map[string]http.Header
+}
+
+func newSwiftStandIn() *httptest.Server {
+	s := &swiftStandIn{
+		containers: map[string]http.Header{},
+		objects:    map[string]map[string][]byte{},
+		headers:    map[string]map[string]http.Header{},
+	}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/v1.0" {
+			if r.Header.Get("X-Auth-User") != "SLOS1-1:user" || r.Header.Get("X-Auth-Key") != "key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-Storage-Url", server.URL+"/v1/AUTH_test")
+			w.Header().Set("X-Auth-Token", "token")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if r.Header.Get("X-Auth-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.serve(w, r)
+	}))
+	return server
+}
+
+func (s *swiftStandIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	path := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v1/AUTH_test/"), "/", 2)
+	container := path[0]
+	containerHeaders, containerFound := s.containers[container]
+
+	if len(path) == 1 {
+		switch r.Method {
+		case "PUT":
+			s.containers[container] = http.Header{}
+			s.objects[container] = map[string][]byte{}
+			s.headers[container] = map[string]http.Header{}
+			copySwiftHeaders(s.containers[container], r.Header, "X-Container-")
+			w.WriteHeader(http.StatusCreated)
+		case "POST", "HEAD", "GET", "DELETE":
+			if !containerFound {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			switch r.Method {
+			case "POST":
+				copySwiftHeaders(containerHeaders, r.Header, "X-Container-")
+				w.WriteHeader(http.StatusNoContent)
+			case "HEAD":
+				for k, v := range containerHeaders {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(http.StatusNoContent)
+			case "GET":
+				names := []string{}
+				for name := range s.objects[container] {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(strings.Join(names, "\n")))
+			case "DELETE":
+				if len(s.objects[container]) > 0 {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				delete(s.containers, container)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+		return
+	}
+
+	if !containerFound {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	object := path[1]
+	content, objectFound := s.objects[container][object]
+
+	switch r.Method {
+	case "PUT":
+		data, _ := ioutil.ReadAll(r.Body)
+		hash := md5.Sum(data)
+		s.objects[container][object] = data
+		s.headers[container][object] = http.Header{
+			"Content-Type": []string{r.Header.Get("Content-Type")},
+			"Etag":         []string{hex.EncodeToString(hash[:])},
+		}
+		copySwiftHeaders(s.headers[container][object], r.Header, "X-Object-Meta-")
+		w.Header().Set("Etag", hex.EncodeToString(hash[:]))
+		w.WriteHeader(http.StatusCreated)
+	case "POST", "HEAD", "DELETE":
+		if !objectFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "POST":
+			headers := http.Header{
+				"Content-Type": []string{r.Header.Get("Content-Type")},
+				"Etag":         s.headers[container][object]["Etag"],
+			}
+			copySwiftHeaders(headers, r.Header, "X-Object-Meta-")
+			s.headers[container][object] = headers
+			w.WriteHeader(http.StatusAccepted)
+		case "HEAD":
+			for k, v := range s.headers[container][object] {
+				w.Header()[k] = v
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+		case "DELETE":
+			delete(s.objects[container], object)
+			delete(s.headers[container], object)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func copySwiftHeaders(dst http.Header, src http.Header, prefix string) {
+	for k, v := range src {
+		if strings.HasPrefix(k, "X-Remove-") {
+			dst.Del("X-" + strings.TrimPrefix(k, "X-Remove-"))
+		} else if strings.HasPrefix(k, prefix) {
+			dst[k] = v
+		}
+	}
+}
+
+func TestSwiftClient_Authentication(t *testing.T) {
+	server := newSwiftStandIn()
+	defer server.Close()
+
+	_, err := newSwiftClient(server.URL+"/auth/v1.0", "SLOS1-1:user", "wrong", time.Minute)
+	if err == nil {
+		t.Fatal("Expected an authentication error")
+	}
+
+	client, err := newSwiftClient(server.URL+"/auth/v1.0", "SLOS1-1:user", "key", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.storageUrl != server.URL+"/v1/AUTH_test" {
+		t.Fatalf("Unexpected storage URL %s", client.storageUrl)
+	}
+}
+
+func TestSwiftClient_Container(t *testing.T) {
+	server := newSwiftStandIn()
+	defer server.Close()
+
+	client, err := newSwiftClient(server.URL+"/auth/v1.0", "SLOS1-1:user", "key", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.HeadContainer("bootstrap")
+	if !isSwiftNotFound(err) {
+		t.Fatalf("Expected a not found error, got %v", err)
+	}
+
+	err = client.PutContainer("bootstrap", map[string]string{
+		"X-Container-Read":             ".r:*",
+		"X-Container-Meta-Quota-Bytes": "1024",
+		"X-Container-Meta-Owner":       "ops",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers, err := client.HeadContainer("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.Get("X-Container-Read") != ".r:*" {
+		t.Fatalf("Unexpected read ACL %s", headers.Get("X-Container-Read"))
+	}
+	metadata := swiftMetadata(headers, swiftContainerMetaPrefix)
+	if metadata["owner"] != "ops" || metadata[swiftQuotaBytes] != "1024" {
+		t.Fatalf("Unexpected metadata %v", metadata)
+	}
+
+	err = client.PostContainer("bootstrap", map[string]string{
+		"X-Remove-Container-Read":       "x",
+		"X-Remove-Container-Meta-Owner": "x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers, err = client.HeadContainer("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.Get("X-Container-Read") != "" {
+		t.Fatalf("Read ACL was not removed: %s", headers.Get("X-Container-Read"))
+	}
+	if _, ok := swiftMetadata(headers, swiftContainerMetaPrefix)["owner"]; ok {
+		t.Fatal("Metadata was not removed")
+	}
+
+	err = client.DeleteContainer("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSwiftClient_Object(t *testing.T) {
+	server := newSwiftStandIn()
+	defer server.Close()
+
+	client, err := newSwiftClient(server.URL+"/auth/v1.0", "SLOS1-1:user", "key", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.PutContainer("bootstrap", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "#!/bin/sh\necho bootstrap\n"
+	etag, err := client.PutObject("bootstrap", "scripts/init.sh", strings.NewReader(content),
+		map[string]string{"Content-Type": "text/x-sh", "X-Object-Meta-Version": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := objectStorageObjectHash(content, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etag != hash {
+		t.Fatalf("ETag %s doesn't match the content hash %s", etag, hash)
+	}
+
+	headers, err := client.HeadObject("bootstrap", "scripts/init.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.Get("Content-Type") != "text/x-sh" || swiftMetadata(headers, swiftObjectMetaPrefix)["version"] != "1" {
+		t.Fatalf("Unexpected object headers %v", headers)
+	}
+
+	objects, err := client.ListObjects("bootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || objects[0] != "scripts/init.sh" {
+		t.Fatalf("Unexpected objects %v", objects)
+	}
+
+	// Containers with objects can't be deleted.
+	err = client.DeleteContainer("bootstrap")
+	if err == nil {
+		t.Fatal("Expected an error deleting a container with objects")
+	}
+
+	err = client.DeleteObject("bootstrap", "scripts/init.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.HeadObject("bootstrap", "scripts/init.sh")
+	if !isSwiftNotFound(err) {
+		t.Fatalf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestValidateSwiftMetadata(t *testing.T) {
+	_, errs := validateSwiftMetadata(map[string]interface{}{"owner": "ops", "build-id": "42"}, "metadata")
+	if len(errs) != 0 {
+		t.Errorf("Expected lower case keys to be valid, got %v", errs)
+	}
+
+	_, errs = validateSwiftMetadata(map[string]interface{}{"Owner": "ops"}, "metadata")
+	if len(errs) != 1 {
+		t.Errorf("Expected an error for the key Owner, got %v", errs)
+	}
+}
@@ -46,39 +46,59 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"softlayer_ssh_key":        dataSourceSoftLayerSSHKey(),
-			"softlayer_image_template": dataSourceSoftLayerImageTemplate(),
-			"softlayer_vlan":           dataSourceSoftLayerVlan(),
-			"softlayer_dns_domain":     dataSourceSoftLayerDnsDomain(),
+			"softlayer_ssh_key":           dataSourceSoftLayerSSHKey(),
+			"softlayer_image_template":    dataSourceSoftLayerImageTemplate(),
+			"softlayer_vlan":              dataSourceSoftLayerVlan(),
+			"softlayer_dns_domain":        dataSourceSoftLayerDnsDomain(),
+			"softlayer_network_storage":   dataSourceSoftLayerNetworkStorage(),
+			"softlayer_subnet":            dataSourceSoftLayerSubnet(),
+			"softlayer_ip_address":        dataSourceSoftLayerIpAddress(),
+			"softlayer_fw_hardware_rules": dataSourceSoftLayerFwHardwareRules(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"softlayer_virtual_guest":               resourceSoftLayerVirtualGuest(),
-			"softlayer_bare_metal":                  resourceSoftLayerBareMetal(),
-			"softlayer_ssh_key":                     resourceSoftLayerSSHKey(),
-			"softlayer_dns_domain_record":           resourceSoftLayerDnsDomainRecord(),
-			"softlayer_dns_domain":                  resourceSoftLayerDnsDomain(),
-			"softlayer_lb_vpx":                      resourceSoftLayerLbVpx(),
-			"softlayer_lb_vpx_vip":                  resourceSoftLayerLbVpxVip(),
-			"softlayer_lb_vpx_service":              resourceSoftLayerLbVpxService(),
-			"softlayer_lb_vpx_ha":                   resourceSoftLayerLbVpxHa(),
-			"softlayer_lb_local":                    resourceSoftLayerLbLocal(),
-			"softlayer_lb_local_service_group":      resourceSoftLayerLbLocalServiceGroup(),
-			"softlayer_lb_local_service":            resourceSoftLayerLbLocalService(),
-			"softlayer_security_certificate":        resourceSoftLayerSecurityCertificate(),
-			"softlayer_user":                        resourceSoftLayerUser(),
-			"softlayer_objectstorage_account":       resourceSoftLayerObjectStorageAccount(),
-			"softlayer_provisioning_hook":           resourceSoftLayerProvisioningHook(),
-			"softlayer_scale_policy":                resourceSoftLayerScalePolicy(),
-			"softlayer_scale_group":                 resourceSoftLayerScaleGroup(),
-			"softlayer_basic_monitor":               resourceSoftLayerBasicMonitor(),
-			"softlayer_vlan":                        resourceSoftLayerVlan(),
-			"softlayer_global_ip":                   resourceSoftLayerGlobalIp(),
-			"softlayer_fw_hardware_dedicated":       resourceSoftLayerFwHardwareDedicated(),
-			"softlayer_fw_hardware_dedicated_rules": resourceSoftLayerFwHardwareDedicatedRules(),
-			"softlayer_file_storage":                resourceSoftLayerFileStorage(),
-			"softlayer_block_storage":               resourceSoftLayerBlockStorage(),
-			"softlayer_dns_secondary":               resourceSoftLayerDnsSecondary(),
+			"softlayer_virtual_guest":                    resourceSoftLayerVirtualGuest(),
+			"softlayer_bare_metal":                       resourceSoftLayerBareMetal(),
+			"softlayer_ssh_key":                          resourceSoftLayerSSHKey(),
+			"softlayer_dns_domain_record":                resourceSoftLayerDnsDomainRecord(),
+			"softlayer_dns_domain":                       resourceSoftLayerDnsDomain(),
+			"softlayer_lb_vpx":                           resourceSoftLayerLbVpx(),
+			"softlayer_lb_vpx_vip":                       resourceSoftLayerLbVpxVip(),
+			"softlayer_lb_vpx_service":                   resourceSoftLayerLbVpxService(),
+			"softlayer_lb_vpx_ha":                        resourceSoftLayerLbVpxHa(),
+			"softlayer_lb_local":                         resourceSoftLayerLbLocal(),
+			"softlayer_lb_local_service_group":           resourceSoftLayerLbLocalServiceGroup(),
+			"softlayer_lb_local_service":                 resourceSoftLayerLbLocalService(),
+			"softlayer_security_certificate":             resourceSoftLayerSecurityCertificate(),
+			"softlayer_user":                             resourceSoftLayerUser(),
+			"softlayer_objectstorage_account":            resourceSoftLayerObjectStorageAccount(),
+			"softlayer_provisioning_hook":                resourceSoftLayerProvisioningHook(),
+			"softlayer_scale_policy":                     resourceSoftLayerScalePolicy(),
+			"softlayer_scale_group":                      resourceSoftLayerScaleGroup(),
+			"softlayer_basic_monitor":                    resourceSoftLayerBasicMonitor(),
+			"softlayer_vlan":                             resourceSoftLayerVlan(),
+			"softlayer_global_ip":                        resourceSoftLayerGlobalIp(),
+			"softlayer_fw_hardware_dedicated":            resourceSoftLayerFwHardwareDedicated(),
+			"softlayer_fw_hardware_dedicated_rules":      resourceSoftLayerFwHardwareDedicatedRules(),
+			"softlayer_file_storage":                     resourceSoftLayerFileStorage(),
+			"softlayer_block_storage":                    resourceSoftLayerBlockStorage(),
+			"softlayer_dns_secondary":                    resourceSoftLayerDnsSecondary(),
+			"softlayer_storage_snapshot":                 resourceSoftLayerStorageSnapshot(),
+			"softlayer_storage_replica":                  resourceSoftLayerStorageReplica(),
+			"softlayer_storage_access":                   resourceSoftLayerStorageAccess(),
+			"softlayer_objectstorage_container":          resourceSoftLayerObjectStorageContainer(),
+			"softlayer_objectstorage_object":             resourceSoftLayerObjectStorageObject(),
+			"softlayer_image_template":                   resourceSoftLayerImageTemplate(),
+			"softlayer_subnet":                           resourceSoftLayerSubnet(),
+			"softlayer_reverse_dns_record":               resourceSoftLayerReverseDnsRecord(),
+			"softlayer_security_group":                   resourceSoftLayerSecurityGroup(),
+			"softlayer_security_group_rule":              resourceSoftLayerSecurityGroupRule(),
+			"softlayer_security_group_attachment":        resourceSoftLayerSecurityGroupAttachment(),
+			"softlayer_fw_hardware":                      resourceSoftLayerFwHardware(),
+			"softlayer_fw_hardware_rules":                resourceSoftLayerFwHardwareRules(),
+			"softlayer_network_gateway":                  resourceSoftLayerNetworkGateway(),
+			"softlayer_network_gateway_vlan_association": resourceSoftLayerNetworkGatewayVlanAssociation(),
+			"softlayer_ipsec_vpn":                        resourceSoftLayerIpsecVpn(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -3,6 +3,7 @@ package softlayer
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +19,10 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 )
 
+const (
+	bareMetalPackageType = "BARE_METAL_CPU"
+)
+
 func resourceSoftLayerBareMetal() *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceSoftLayerBareMetalCreate,
@@ -27,6 +32,8 @@ func resourceSoftLayerBareMetal() *schema.Resource {
 		Exists:   resourceSoftLayerBareMetalExists,
 		Importer: &schema.ResourceImporter{},
 
+		CustomizeDiff: validateBareMetalOrder,
+
 		Schema: map[string]*schema.Schema{
 			"hostname": {
 				Type:        schema.TypeString,
@@ -298,6 +305,50 @@ func resourceSoftLayerBareMetal() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"ipmi_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ipmi_username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ipmi_password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"os_credentials": {
+				Type:      schema.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+
+			"trunk_vlan_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set: func(v interface{}) int {
+					return v.(int)
+				},
+			},
 		},
 	}
 }
@@ -465,6 +516,14 @@ func resourceSoftLayerBareMetalCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	// Set VLAN trunks
+	if trunkVlanIds := d.Get("trunk_vlan_ids").(*schema.Set); trunkVlanIds.Len() > 0 {
+		err = setHardwareVlanTrunks(id, d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceSoftLayerBareMetalRead(d, meta)
 }
 
@@ -482,10 +541,15 @@ func resourceSoftLayerBareMetalRead(d *schema.ResourceData, meta interface{}) er
 			"notes,userData[value],tagReferences[id,tag[name]]," +
 			"hourlyBillingFlag," +
 			"datacenter[id,name,longName]," +
-			"primaryNetworkComponent[networkVlan[id,primaryRouter,vlanNumber],maxSpeed]," +
-			"primaryBackendNetworkComponent[networkVlan[id,primaryRouter,vlanNumber],maxSpeed,redundancyEnabledFlag]," +
+			"primaryNetworkComponent[id,networkVlan[id,primaryRouter,vlanNumber],maxSpeed]," +
+			"primaryBackendNetworkComponent[id,networkVlan[id,primaryRouter,vlanNumber],maxSpeed,redundancyEnabledFlag]," +
 			"memoryCapacity,powerSupplyCount," +
-			"operatingSystem[softwareLicense[softwareDescription[referenceCode]]]",
+			"operatingSystem[softwareLicense[softwareDescription[referenceCode]],passwords[username,password]]," +
+			"networkManagementIpAddress,remoteManagementAccounts[username,password]," +
+			"fixedConfigurationPreset[keyName]," +
+			"billingItem[id,package[keyName],item[keyName]," +
+			"children[categoryCode,item[keyName,capacity]]," +
+			"orderItem[storageGroups[arraySize,arrayTypeId,hardDrives]]]",
 	).GetObject()
 
 	if err != nil {
@@ -557,6 +621,21 @@ func resourceSoftLayerBareMetalRead(d *schema.ResourceData, meta interface{}) er
 		d.Set("os_reference_code", *result.OperatingSystem.SoftwareLicense.SoftwareDescription.ReferenceCode)
 	}
 
+	// Rebuild the order arguments so that imported servers plan with no changes.
+	setBareMetalOrderAttributes(d, result)
+
+	d.Set("ipmi_ip_address", sl.Get(result.NetworkManagementIpAddress, nil))
+	if len(result.RemoteManagementAccounts) > 0 {
+		d.Set("ipmi_username", sl.Get(result.RemoteManagementAccounts[0].Username, nil))
+		d.Set("ipmi_password", sl.Get(result.RemoteManagementAccounts[0].Password, nil))
+	}
+
+	var osPasswords []datatypes.Software_Component_Password
+	if result.OperatingSystem != nil {
+		osPasswords = result.OperatingSystem.Passwords
+	}
+	d.Set("os_credentials", flattenOsCredentials(osPasswords))
+
 	tagReferences := result.TagReferences
 	tagReferencesLen := len(tagReferences)
 	if tagReferencesLen > 0 {
@@ -567,17 +646,55 @@ func resourceSoftLayerBareMetalRead(d *schema.ResourceData, meta interface{}) er
 		d.Set("tags", tags)
 	}
 
+	trunkVlanIds, err := getVlanTrunks(
+		meta.(ProviderConfig).SoftLayerSession(),
+		hardwareNetworkComponentId(result.PrimaryNetworkComponent),
+		hardwareNetworkComponentId(result.PrimaryBackendNetworkComponent),
+	)
+	if err != nil {
+		return fmt.Errorf("Error retrieving bare metal server VLAN trunks: %s", err)
+	}
+	d.Set("trunk_vlan_ids", trunkVlanIds)
+
 	connInfo := map[string]string{"type": "ssh"}
 	if !*result.PrivateNetworkOnlyFlag && result.PrimaryIpAddress != nil {
 		connInfo["host"] = *result.PrimaryIpAddress
 	} else {
 		connInfo["host"] = *result.PrimaryBackendIpAddress
 	}
+	if username, password := getRootCredentials(osPasswords); password != "" {
+		connInfo["user"] = username
+		connInfo["password"] = password
+	}
 	d.SetConnInfo(connInfo)
 
 	return nil
 }
 
+func flattenOsCredentials(passwords []datatypes.Software_Component_Password) []map[string]interface{} {
+	credentials := make([]map[string]interface{}, 0, len(passwords))
+	for _, password := range passwords {
+		credentials = append(credentials, map[string]interface{}{
+			"username": sl.Get(password.Username, ""),
+			"password": sl.Get(password.Password, ""),
+		})
+	}
+	return credentials
+}
+
+// Returns the administrator credentials of the operating system. Linux uses root and Windows uses Administrator.
+func getRootCredentials(passwords []datatypes.Software_Component_Password) (string, string) {
+	for _, password := range passwords {
+		if password.Username == nil || password.Password == nil {
+			continue
+		}
+		if *password.Username == "root" || *password.Username == "Administrator" {
+			return *password.Username, *password.Password
+		}
+	}
+	return "", ""
+}
+
 func resourceSoftLayerBareMetalUpdate(d *schema.ResourceData, meta interface{}) error {
 	id, _ := strconv.Atoi(d.Id())
 
@@ -595,6 +712,13 @@ func resourceSoftLayerBareMetalUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("trunk_vlan_ids") {
+		err := setHardwareVlanTrunks(id, d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -744,6 +868,206 @@ func setHardwareNotes(id int, d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// Sets the arguments used to order the server from its fixed configuration preset or billing items.
+func setBareMetalOrderAttributes(d *schema.ResourceData, hardware datatypes.Hardware) {
+	if hardware.FixedConfigurationPreset != nil && hardware.FixedConfigurationPreset.KeyName != nil {
+		d.Set("fixed_config_preset", *hardware.FixedConfigurationPreset.KeyName)
+		return
+	}
+
+	billingItem := hardware.BillingItem
+	if billingItem == nil {
+		return
+	}
+
+	if billingItem.Package != nil && billingItem.Package.KeyName != nil {
+		d.Set("package_key_name", *billingItem.Package.KeyName)
+	}
+
+	if billingItem.Item != nil && billingItem.Item.KeyName != nil {
+		d.Set("process_key_name", *billingItem.Item.KeyName)
+	}
+
+	disks := make(map[int]string)
+	for _, child := range billingItem.Children {
+		if child.CategoryCode == nil || child.Item == nil || child.Item.KeyName == nil {
+			continue
+		}
+
+		categoryCode := *child.CategoryCode
+		switch {
+		case categoryCode == "os":
+			d.Set("os_key_name", *child.Item.KeyName)
+		case categoryCode == "bandwidth":
+			if child.Item.Capacity != nil {
+				d.Set("public_bandwidth", int(*child.Item.Capacity))
+			}
+		case categoryCode == "monitoring":
+			d.Set("tcp_monitoring", *child.Item.KeyName == "MONITORING_HOST_PING_AND_TCP_SERVICE")
+		case strings.HasPrefix(categoryCode, "disk"):
+			// Disks are sold as disk0, disk1, ... Other disk categories such as disk_controller are skipped.
+			if index, err := strconv.Atoi(strings.TrimPrefix(categoryCode, "disk")); err == nil {
+				disks[index] = *child.Item.KeyName
+			}
+		}
+	}
+
+	if len(disks) > 0 {
+		indexes := make([]int, 0, len(disks))
+		for index := range disks {
+			indexes = append(indexes, index)
+		}
+		sort.Ints(indexes)
+
+		diskKeyNames := make([]string, 0, len(disks))
+		for _, index := range indexes {
+			diskKeyNames = append(diskKeyNames, disks[index])
+		}
+		d.Set("disk_key_names", diskKeyNames)
+	}
+
+	if billingItem.OrderItem != nil && len(billingItem.OrderItem.StorageGroups) > 0 {
+		d.Set("storage_groups", flattenStorageGroups(d, billingItem.OrderItem.StorageGroups))
+	}
+}
+
+func flattenStorageGroups(d *schema.ResourceData, storageGroups []datatypes.Configuration_Storage_Group_Order) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(storageGroups))
+	for i, storageGroup := range storageGroups {
+		group := map[string]interface{}{
+			"array_type_id": sl.Get(storageGroup.ArrayTypeId, 0),
+			"hard_drives":   storageGroup.HardDrives,
+		}
+		if storageGroup.ArraySize != nil {
+			group["array_size"] = int(*storageGroup.ArraySize)
+		}
+
+		// The partition template isn't kept with the order. Keep the configured value.
+		if partitionTemplateId, ok := d.GetOk(fmt.Sprintf("storage_groups.%d.partition_template_id", i)); ok {
+			group["partition_template_id"] = partitionTemplateId.(int)
+		}
+		result = append(result, group)
+	}
+	return result
+}
+
+func setHardwareVlanTrunks(id int, d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	result, err := services.GetHardwareService(sess).Id(id).
+		Mask("primaryNetworkComponent[id],primaryBackendNetworkComponent[id]").
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving bare metal server network components: %s", err)
+	}
+
+	err = updateVlanTrunks(sess, d,
+		hardwareNetworkComponentId(result.PrimaryNetworkComponent),
+		hardwareNetworkComponentId(result.PrimaryBackendNetworkComponent))
+	if err != nil {
+		return fmt.Errorf("Could not set VLAN trunks on bare metal server %d: %s", id, err)
+	}
+
+	return nil
+}
+
+func hardwareNetworkComponentId(component *datatypes.Network_Component) *int {
+	if component == nil {
+		return nil
+	}
+	return component.Id
+}
+
+// Returns IDs of the VLANs trunked to the public and private network components.
+// Trunks are kept on the switch port, so they are read through the uplink of each component.
+func getVlanTrunks(sess *session.Session, componentIds ...*int) ([]int, error) {
+	vlanIds := make([]int, 0)
+	for _, componentId := range componentIds {
+		if componentId == nil {
+			continue
+		}
+
+		component, err := services.GetNetworkComponentService(sess).
+			Id(*componentId).
+			Mask("uplinkComponent[networkVlanTrunks[networkVlan[id]]]").
+			GetObject()
+		if err != nil {
+			return nil, err
+		}
+
+		if component.UplinkComponent == nil {
+			continue
+		}
+		for _, trunk := range component.UplinkComponent.NetworkVlanTrunks {
+			if trunk.NetworkVlan != nil && trunk.NetworkVlan.Id != nil {
+				vlanIds = append(vlanIds, *trunk.NetworkVlan.Id)
+			}
+		}
+	}
+	return vlanIds, nil
+}
+
+// Adds and removes VLAN trunks so that trunk_vlan_ids is trunked to the network components.
+// Public VLANs are trunked to the public component and private VLANs to the private component.
+func updateVlanTrunks(sess *session.Session, d *schema.ResourceData, publicComponentId, privateComponentId *int) error {
+	o, n := d.GetChange("trunk_vlan_ids")
+	oldIds := o.(*schema.Set)
+	newIds := n.(*schema.Set)
+
+	// Remove deleted trunks first to free the ports for the new ones.
+	removed, err := groupVlansByComponent(sess, oldIds.Difference(newIds).List(), publicComponentId, privateComponentId)
+	if err != nil {
+		return err
+	}
+	for componentId, vlans := range removed {
+		log.Printf("[INFO] Removing VLAN trunks from network component %d", componentId)
+		_, err = services.GetNetworkComponentService(sess).Id(componentId).RemoveNetworkVlanTrunks(vlans)
+		if err != nil {
+			return err
+		}
+	}
+
+	added, err := groupVlansByComponent(sess, newIds.Difference(oldIds).List(), publicComponentId, privateComponentId)
+	if err != nil {
+		return err
+	}
+	for componentId, vlans := range added {
+		log.Printf("[INFO] Adding VLAN trunks to network component %d", componentId)
+		_, err = services.GetNetworkComponentService(sess).Id(componentId).AddNetworkVlanTrunks(vlans)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func groupVlansByComponent(
+	sess *session.Session,
+	vlanIds []interface{},
+	publicComponentId, privateComponentId *int) (map[int][]datatypes.Network_Vlan, error) {
+
+	vlansByComponent := make(map[int][]datatypes.Network_Vlan)
+	for _, vlanId := range vlanIds {
+		vlan, err := services.GetNetworkVlanService(sess).Id(vlanId.(int)).Mask("id,networkSpace").GetObject()
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving vlan %d: %s", vlanId.(int), err)
+		}
+
+		componentId := privateComponentId
+		if vlan.NetworkSpace != nil && *vlan.NetworkSpace == "PUBLIC" {
+			componentId = publicComponentId
+		}
+
+		if componentId == nil {
+			return nil, fmt.Errorf("No network component to trunk the %s vlan %d", sl.Get(vlan.NetworkSpace, ""), vlanId.(int))
+		}
+
+		vlansByComponent[*componentId] = append(vlansByComponent[*componentId], datatypes.Network_Vlan{Id: vlan.Id})
+	}
+	return vlansByComponent, nil
+}
+
 // Returns a price from an item list.
 // Example usage : getItemPriceId(items, 'server', 'INTEL_XEON_2690_2_60')
 func getItemPriceId(items []datatypes.Product_Item, categoryCode string, keyName string) (datatypes.Product_Item_Price, error) {
@@ -766,7 +1090,13 @@ func getItemPriceId(items []datatypes.Product_Item, categoryCode string, keyName
 		fmt.Errorf("Could not find the matching item with categorycode %s and keyName %s. Available item(s) is(are) %s", categoryCode, keyName, availableItems)
 }
 
-func getMonthlyBareMetalOrder(d *schema.ResourceData, meta interface{}) (datatypes.Container_Product_Order, error) {
+func getMonthlyBareMetalOrder(d resourceDataGetter, meta interface{}) (datatypes.Container_Product_Order, error) {
+	return getMonthlyHardwareOrder(d, meta, bareMetalPackageType)
+}
+
+// Builds a monthly order for a server of a package of the package type. Bare metal servers and network
+// gateway members are ordered in the same way.
+func getMonthlyHardwareOrder(d resourceDataGetter, meta interface{}, packageType string) (datatypes.Container_Product_Order, error) {
 	sess := meta.(ProviderConfig).SoftLayerSession()
 	// Validate attributes for monthly bare metal server ordering.
 	if d.Get("hourly_billing").(bool) {
@@ -788,21 +1118,31 @@ func getMonthlyBareMetalOrder(d *schema.ResourceData, meta interface{}) (datatyp
 		return datatypes.Container_Product_Order{}, fmt.Errorf("The attribute 'os_key_name' is not defined.")
 	}
 
-	dc, err := location.GetDatacenterByName(sess, datacenter.(string), "id")
+	// 1. Find a package id using monthly bare metal package key name.
+	pkg, err := getPackageByModel(sess, model.(string), packageType)
 	if err != nil {
 		return datatypes.Container_Product_Order{}, err
 	}
 
-	// 1. Find a package id using monthly bare metal package key name.
-	pkg, err := getPackageByModel(sess, model.(string))
+	if pkg.Id == nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+
+	// Check the package is available in the data center before looking up the data center ID.
+	err = validatePackageDatacenter(sess, *pkg.Id, model.(string), datacenter.(string))
 	if err != nil {
 		return datatypes.Container_Product_Order{}, err
 	}
 
-	if pkg.Id == nil {
+	dc, err := location.GetDatacenterByName(sess, datacenter.(string), "id")
+	if err != nil {
 		return datatypes.Container_Product_Order{}, err
 	}
 
+	if dc.Id == nil {
+		return datatypes.Container_Product_Order{}, fmt.Errorf("No data centers matching %s could be found", datacenter.(string))
+	}
+
 	// 2. Get all prices for the package
 	items, err := product.GetPackageProducts(sess, *pkg.Id, "id,categories,capacity,description,units,keyName,prices[id,categories[id,name,categoryCode]]")
 	if err != nil {
@@ -1007,7 +1347,7 @@ func setCommonBareMetalOrderOptions(d *schema.ResourceData, meta interface{}, or
 }
 
 // Find price item using network options
-func findNetworkItemPriceId(items []datatypes.Product_Item, d *schema.ResourceData) (datatypes.Product_Item_Price, error) {
+func findNetworkItemPriceId(items []datatypes.Product_Item, d resourceDataGetter) (datatypes.Product_Item_Price, error) {
 	networkSpeed := d.Get("network_speed").(int)
 	redundantNetwork := d.Get("redundant_network").(bool)
 	unbondedNetwork := d.Get("unbonded_network").(bool)
@@ -1016,6 +1356,7 @@ func findNetworkItemPriceId(items []datatypes.Product_Item, d *schema.ResourceDa
 	networkSpeedStr := "_MBPS_"
 	redundantNetworkStr := ""
 	unbondedNetworkStr := ""
+	availableNetworks := ""
 
 	if networkSpeed < 1000 {
 		networkSpeedStr = strconv.Itoa(networkSpeed) + networkSpeedStr
@@ -1032,6 +1373,9 @@ func findNetworkItemPriceId(items []datatypes.Product_Item, d *schema.ResourceDa
 
 	for _, item := range items {
 		for _, itemCategory := range item.Categories {
+			if *itemCategory.CategoryCode == "port_speed" {
+				availableNetworks = availableNetworks + *item.KeyName + " ( " + *item.Description + " ) , "
+			}
 			if *itemCategory.CategoryCode == "port_speed" &&
 				strings.HasPrefix(*item.KeyName, networkSpeedStr) &&
 				strings.Contains(*item.KeyName, redundantNetworkStr) &&
@@ -1051,12 +1395,12 @@ func findNetworkItemPriceId(items []datatypes.Product_Item, d *schema.ResourceDa
 		}
 	}
 	return datatypes.Product_Item_Price{},
-		fmt.Errorf("Could not find the network with %s, %s, %s, and private_network_only = %t",
-			networkSpeedStr, redundantNetworkStr, unbondedNetworkStr, privateNetworkOnly)
+		fmt.Errorf("Could not find the network with %s, %s, %s, and private_network_only = %t. Available item(s) is(are) %s",
+			networkSpeedStr, redundantNetworkStr, unbondedNetworkStr, privateNetworkOnly, availableNetworks)
 }
 
 // Find memory price item using memory size.
-func findMemoryItemPriceId(items []datatypes.Product_Item, d *schema.ResourceData) (datatypes.Product_Item_Price, error) {
+func findMemoryItemPriceId(items []datatypes.Product_Item, d resourceDataGetter) (datatypes.Product_Item_Price, error) {
 	memory := d.Get("memory").(int)
 	memoryStr := "RAM_" + strconv.Itoa(memory) + "_GB"
 	availableMemories := ""
@@ -1080,8 +1424,8 @@ func findMemoryItemPriceId(items []datatypes.Product_Item, d *schema.ResourceDat
 		fmt.Errorf("Could not find the price item for %d GB memory. Available items are %s", memory, availableMemories)
 }
 
-// Find a bare metal package object using a package key name
-func getPackageByModel(sess *session.Session, model string) (datatypes.Product_Package, error) {
+// Find a package object of the package type using a package key name
+func getPackageByModel(sess *session.Session, model string, packageType string) (datatypes.Product_Package, error) {
 	objectMask := "id,keyName,name,description,isActive,type[keyName]"
 	service := services.GetProductPackageService(sess)
 	availableModels := ""
@@ -1090,7 +1434,7 @@ func getPackageByModel(sess *session.Session, model string) (datatypes.Product_P
 	packages, err := service.Mask(objectMask).
 		Filter(
 			filter.Build(
-				filter.Path("type.keyName").Eq("BARE_METAL_CPU"),
+				filter.Path("type.keyName").Eq(packageType),
 			),
 		).GetAllObjects()
 	if err != nil {
@@ -1112,7 +1456,7 @@ func getPackageByModel(sess *session.Session, model string) (datatypes.Product_P
 	return datatypes.Product_Package{}, fmt.Errorf("No custom bare metal package key name for %s. Available package key name(s) is(are) %s", model, availableModels)
 }
 
-func getStorageGroupsFromResourceData(d *schema.ResourceData) []datatypes.Container_Product_Order_Storage_Group {
+func getStorageGroupsFromResourceData(d resourceDataGetter) []datatypes.Container_Product_Order_Storage_Group {
 	storageGroupLists := d.Get("storage_groups").([]interface{})
 	storageGroups := make([]datatypes.Container_Product_Order_Storage_Group, 0)
 
@@ -3,6 +3,7 @@ package softlayer
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -42,6 +43,14 @@ func TestAccSoftLayerBareMetal_Basic(t *testing.T) {
 						"softlayer_bare_metal.terraform-acceptance-test-1", "private_network_only", "false"),
 					resource.TestCheckResourceAttr(
 						"softlayer_bare_metal.terraform-acceptance-test-1", "user_metadata", "{\"value\":\"newvalue\"}"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_bare_metal.terraform-acceptance-test-1", "ipmi_ip_address"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_bare_metal.terraform-acceptance-test-1", "ipmi_username"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_bare_metal.terraform-acceptance-test-1", "ipmi_password"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_bare_metal.terraform-acceptance-test-1", "os_credentials.0.password"),
 					resource.TestCheckResourceAttr(
 						"softlayer_bare_metal.terraform-acceptance-test-1", "fixed_config_preset", "S1270_8GB_2X1TBSATA_NORAID"),
 					CheckStringSet(
@@ -66,6 +75,34 @@ func TestAccSoftLayerBareMetal_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerBareMetal_VlanTrunks(t *testing.T) {
+	var bareMetal datatypes.Hardware
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerBareMetalDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerBareMetalConfig_vlanTrunks,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerBareMetalExists("softlayer_bare_metal.terraform-acceptance-test-trunks", &bareMetal),
+					resource.TestCheckResourceAttr(
+						"softlayer_bare_metal.terraform-acceptance-test-trunks", "trunk_vlan_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckSoftLayerBareMetalConfig_vlanTrunksRemoved,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerBareMetalExists("softlayer_bare_metal.terraform-acceptance-test-trunks", &bareMetal),
+					resource.TestCheckResourceAttr(
+						"softlayer_bare_metal.terraform-acceptance-test-trunks", "trunk_vlan_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSoftLayerBareMetalQuote_Basic(t *testing.T) {
 	var bareMetal datatypes.Hardware
 
@@ -116,6 +153,32 @@ func TestAccSoftLayerBareMetalCustom_Basic(t *testing.T) {
 						"softlayer_bare_metal.terraform-acceptance-test-3", "public_bandwidth", "500"),
 				),
 			},
+
+			{
+				ResourceName:      "softlayer_bare_metal.terraform-acceptance-test-3",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"os_reference_code",
+				},
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerBareMetalCustom_InvalidOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckSoftLayerBareMetalCustom_invalidMemory,
+				ExpectError: regexp.MustCompile("Could not find the price item for 3 GB memory"),
+			},
+			{
+				Config:      testAccCheckSoftLayerBareMetalCustom_invalidDatacenter,
+				ExpectError: regexp.MustCompile("is not available in the data center"),
+			},
 		},
 	})
 }
@@ -211,6 +274,42 @@ resource "softlayer_bare_metal" "terraform-acceptance-test-1" {
 }
 `
 
+const testAccCheckSoftLayerBareMetalConfig_vlanTrunksVlan = `
+resource "softlayer_vlan" "test_trunk_vlan" {
+    name = "test_trunk_vlan"
+    datacenter = "dal01"
+    type = "PRIVATE"
+    primary_subnet_size = 8
+}
+`
+
+const testAccCheckSoftLayerBareMetalConfig_vlanTrunks = testAccCheckSoftLayerBareMetalConfig_vlanTrunksVlan + `
+resource "softlayer_bare_metal" "terraform-acceptance-test-trunks" {
+    hostname = "terraform-test-trunks"
+    domain = "bar.example.com"
+    os_reference_code = "UBUNTU_16_64"
+    datacenter = "dal01"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    fixed_config_preset = "S1270_8GB_2X1TBSATA_NORAID"
+    trunk_vlan_ids = ["${softlayer_vlan.test_trunk_vlan.id}"]
+}
+`
+
+const testAccCheckSoftLayerBareMetalConfig_vlanTrunksRemoved = testAccCheckSoftLayerBareMetalConfig_vlanTrunksVlan + `
+resource "softlayer_bare_metal" "terraform-acceptance-test-trunks" {
+    hostname = "terraform-test-trunks"
+    domain = "bar.example.com"
+    os_reference_code = "UBUNTU_16_64"
+    datacenter = "dal01"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    fixed_config_preset = "S1270_8GB_2X1TBSATA_NORAID"
+}
+`
+
 const testAccCheckSoftLayerBareMetalQuoteConfig_basic = `
 resource "softlayer_bare_metal" "terraform-acceptance-test-2" {
     hostname = "terraform-test2"
@@ -237,3 +336,31 @@ resource "softlayer_bare_metal" "terraform-acceptance-test-3" {
     redundant_power_supply = true
 }
 `
+
+const testAccCheckSoftLayerBareMetalCustom_invalidMemory = `
+resource "softlayer_bare_metal" "terraform-acceptance-test-4" {
+    package_key_name = "2U_DUAL_E52600_12_DRIVES"
+    process_key_name = "INTEL_DUAL_INTEL_XEON_E52620_2_00"
+    memory = 3
+    os_key_name = "OS_WINDOWS_2012_R2_FULL_DC_64_BIT_2"
+    hostname = "cust-bm"
+    domain = "example.com"
+    datacenter = "dal05"
+    network_speed = 1000
+    hourly_billing = false
+}
+`
+
+const testAccCheckSoftLayerBareMetalCustom_invalidDatacenter = `
+resource "softlayer_bare_metal" "terraform-acceptance-test-4" {
+    package_key_name = "2U_DUAL_E52600_12_DRIVES"
+    process_key_name = "INTEL_DUAL_INTEL_XEON_E52620_2_00"
+    memory = 32
+    os_key_name = "OS_WINDOWS_2012_R2_FULL_DC_64_BIT_2"
+    hostname = "cust-bm"
+    domain = "example.com"
+    datacenter = "nowhere01"
+    network_speed = 1000
+    hourly_billing = false
+}
+`
@@ -12,6 +12,7 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 	"regexp"
 	"strings"
+	"time"
 )
 
 func resourceSoftLayerBlockStorage() *schema.Resource {
@@ -23,11 +24,14 @@ func resourceSoftLayerBlockStorage() *schema.Resource {
 		Exists:   resourceSoftLayerBlockStorageExists,
 		Importer: &schema.ResourceImporter{},
 
+		CustomizeDiff: validateStorageOrder(blockStorage),
+
 		Schema: map[string]*schema.Schema{
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageType,
 			},
 
 			"datacenter": {
@@ -39,13 +43,11 @@ func resourceSoftLayerBlockStorage() *schema.Resource {
 			"capacity": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"iops": {
 				Type:     schema.TypeFloat,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"volumename": {
@@ -64,6 +66,20 @@ func resourceSoftLayerBlockStorage() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"origin_volume_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"origin_snapshot_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
 			"os_format_type": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -151,6 +167,22 @@ func resourceSoftLayerBlockStorage() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"access_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  storageAccessAuthoritative,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					mode := v.(string)
+					if mode != storageAccessAuthoritative && mode != storageAccessAdditive {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either '%s' or '%s'", k, storageAccessAuthoritative, storageAccessAdditive))
+					}
+					return
+				},
+			},
+
+			"snapshot_schedule": storageSnapshotScheduleSchema(),
 		},
 	}
 }
@@ -170,7 +202,12 @@ func resourceSoftLayerBlockStorageCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	storageOrderContainer, err := buildStorageProductOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, blockStorage, datacenter)
+	var storageOrderContainer datatypes.Container_Product_Order
+	if d.Get("origin_volume_id").(int) > 0 {
+		storageOrderContainer, err = buildStorageAsAServiceOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, blockStorage, datacenter)
+	} else {
+		storageOrderContainer, err = buildStorageProductOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, blockStorage, datacenter)
+	}
 	if err != nil {
 		return fmt.Errorf("Error while creating storage:%s", err)
 	}
@@ -179,8 +216,14 @@ func resourceSoftLayerBlockStorageCreate(d *schema.ResourceData, meta interface{
 
 	var receipt datatypes.Container_Product_Order_Receipt
 
-	switch storageType {
-	case enduranceType:
+	switch {
+	case d.Get("origin_volume_id").(int) > 0:
+		receipt, err = services.GetProductOrderService(sess).PlaceOrder(
+			buildDuplicateStorageOrderContainer(d, storageOrderContainer, &datatypes.Network_Storage_Iscsi_OS_Type{
+				Id:      osType.Id,
+				KeyName: osType.KeyName,
+			}), sl.Bool(false))
+	case storageType == enduranceType:
 		receipt, err = services.GetProductOrderService(sess).PlaceOrder(
 			&datatypes.Container_Product_Order_Network_Storage_Enterprise{
 				Container_Product_Order: storageOrderContainer,
@@ -189,7 +232,7 @@ func resourceSoftLayerBlockStorageCreate(d *schema.ResourceData, meta interface{
 					KeyName: osType.KeyName,
 				},
 			}, sl.Bool(false))
-	case performanceType:
+	case storageType == performanceType:
 		receipt, err = services.GetProductOrderService(sess).PlaceOrder(
 			&datatypes.Container_Product_Order_Network_PerformanceStorage_Iscsi{
 				Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
@@ -234,6 +277,18 @@ func resourceSoftLayerBlockStorageCreate(d *schema.ResourceData, meta interface{
 
 	log.Printf("[INFO] Storage ID: %s", d.Id())
 
+	// Wait for the duplication of the origin volume to start and to finish
+	if d.Get("origin_volume_id").(int) > 0 {
+		err = waitForStorageTransactionStart(sess, *blockStorage.Id, 10*time.Minute)
+		if err == nil {
+			_, err = waitForStorageTransactions(sess, *blockStorage.Id)
+		}
+		if err != nil {
+			return fmt.Errorf(
+				"Error waiting for storage (%s) to be duplicated: %s", d.Id(), err)
+		}
+	}
+
 	return resourceSoftLayerBlockStorageUpdate(d, meta)
 }
 
@@ -277,7 +332,9 @@ func resourceSoftLayerBlockStorageRead(d *schema.ResourceData, meta interface{})
 	// Read allowed_ip_addresses
 	allowedIpaddressesList := make([]string, 0, len(storage.AllowedIpAddresses))
 	for _, allowedIpaddress := range storage.AllowedIpAddresses {
-		allowedIpaddressesList = append(allowedIpaddressesList, *allowedIpaddress.IpAddress)
+		if isManagedStorageAccess(d, "allowed_ip_addresses", *allowedIpaddress.IpAddress) {
+			allowedIpaddressesList = append(allowedIpaddressesList, *allowedIpaddress.IpAddress)
+		}
 	}
 	d.Set("allowed_ip_addresses", allowedIpaddressesList)
 
@@ -292,7 +349,9 @@ func resourceSoftLayerBlockStorageRead(d *schema.ResourceData, meta interface{})
 		singleVirtualGuest["password"] = *allowedVirtualGuest.AllowedHost.Credential.Password
 		singleVirtualGuest["host_iqn"] = *allowedVirtualGuest.AllowedHost.Name
 		allowedVirtualGuestInfoList = append(allowedVirtualGuestInfoList, singleVirtualGuest)
-		allowedVirtualGuestIdsList = append(allowedVirtualGuestIdsList, *allowedVirtualGuest.Id)
+		if isManagedStorageAccess(d, "allowed_virtual_guest_ids", *allowedVirtualGuest.Id) {
+			allowedVirtualGuestIdsList = append(allowedVirtualGuestIdsList, *allowedVirtualGuest.Id)
+		}
 	}
 	d.Set("allowed_virtual_guest_ids", allowedVirtualGuestIdsList)
 	d.Set("allowed_virtual_guest_info", allowedVirtualGuestInfoList)
@@ -307,7 +366,9 @@ func resourceSoftLayerBlockStorageRead(d *schema.ResourceData, meta interface{})
 		singleHardware["password"] = *allowedHW.AllowedHost.Credential.Password
 		singleHardware["host_iqn"] = *allowedHW.AllowedHost.Name
 		allowedHardwareInfoList = append(allowedHardwareInfoList, singleHardware)
-		allowedHardwareIdsList = append(allowedHardwareIdsList, *allowedHW.Id)
+		if isManagedStorageAccess(d, "allowed_hardware_ids", *allowedHW.Id) {
+			allowedHardwareIdsList = append(allowedHardwareIdsList, *allowedHW.Id)
+		}
 	}
 	d.Set("allowed_hardware_ids", allowedHardwareIdsList)
 	d.Set("allowed_hardware_info", allowedHardwareInfoList)
@@ -316,6 +377,12 @@ func resourceSoftLayerBlockStorageRead(d *schema.ResourceData, meta interface{})
 		d.Set("os_type", *storage.OsType.Name)
 	}
 
+	snapshotSchedules, err := getSnapshotSchedules(sess, storageId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage information: %s", err)
+	}
+	d.Set("snapshot_schedule", snapshotSchedules)
+
 	return nil
 }
 
@@ -335,6 +402,14 @@ func resourceSoftLayerBlockStorageUpdate(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("Error updating storage information: %s", err)
 	}
 
+	// Modify capacity and iops. Create calls Update, so skip new resources which are already ordered with them.
+	if !d.IsNewResource() && (d.HasChange("capacity") || d.HasChange("iops")) {
+		err := modifyStorage(d, sess, storage)
+		if err != nil {
+			return fmt.Errorf("Error updating storage information: %s", err)
+		}
+	}
+
 	// Update allowed_ip_addresses
 	if d.HasChange("allowed_ip_addresses") {
 		err := updateAllowedIpAddresses(d, sess, storage)
@@ -367,6 +442,14 @@ func resourceSoftLayerBlockStorageUpdate(d *schema.ResourceData, meta interface{
 		}
 	}
 
+	// Update snapshot_schedule
+	if d.HasChange("snapshot_schedule") {
+		err := updateSnapshotSchedules(d, sess, storage)
+		if err != nil {
+			return fmt.Errorf("Error updating storage information: %s", err)
+		}
+	}
+
 	return resourceSoftLayerBlockStorageRead(d, meta)
 }
 
@@ -63,6 +63,27 @@ func TestAccSoftLayerBlockStorage_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerBlockStorage_Duplicate(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerBlockStorageConfig_duplicate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerBlockStorageExists("softlayer_block_storage.bs_origin"),
+					testAccCheckSoftLayerBlockStorageExists("softlayer_block_storage.bs_duplicate"),
+					testAccCheckSoftLayerResources("softlayer_block_storage.bs_duplicate", "origin_volume_id",
+						"softlayer_block_storage.bs_origin", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_duplicate", "capacity", "40"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSoftLayerBlockStorageExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -160,3 +181,24 @@ resource "softlayer_block_storage" "bs_performance" {
         allowed_ip_addresses = [ "${softlayer_virtual_guest.storagevm2.ipv4_address_private}" ]
 }
 `
+
+const testAccCheckSoftLayerBlockStorageConfig_duplicate = `
+resource "softlayer_block_storage" "bs_origin" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 2
+        snapshot_capacity = 10
+        os_format_type = "Linux"
+}
+
+resource "softlayer_block_storage" "bs_duplicate" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 40
+        iops = 2
+        snapshot_capacity = 10
+        os_format_type = "Linux"
+        origin_volume_id = "${softlayer_block_storage.bs_origin.id}"
+}
+`
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,15 +24,22 @@ import (
 const (
 	storagePerformancePackageType = "ADDITIONAL_SERVICES_PERFORMANCE_STORAGE"
 	storageEndurancePackageType   = "ADDITIONAL_SERVICES_ENTERPRISE_STORAGE"
+	storageAsAServicePackageType  = "STORAGE_AS_A_SERVICE"
 	storageMask                   = "id,billingItem.orderItem.order.id"
 	storageDetailMask             = "id,capacityGb,iops,storageType,username,serviceResourceBackendIpAddress,properties[type]" +
 		",serviceResourceName,allowedIpAddresses,allowedSubnets,allowedVirtualGuests[id,allowedHost[name,credential[username,password]]],allowedHardware[id,allowedHost[name,credential[username,password]]],snapshotCapacityGb,osType,notes"
 	itemMask        = "id,capacity,description,units,keyName,prices[id,categories[id,name,categoryCode],capacityRestrictionMinimum,capacityRestrictionMaximum,locationGroupId]"
+	rangeItemMask   = "id,capacity,capacityMinimum,capacityMaximum,description,units,keyName,prices[id,categories[id,name,categoryCode],capacityRestrictionMinimum,capacityRestrictionMaximum,locationGroupId]"
 	enduranceType   = "Endurance"
 	performanceType = "Performance"
 	fileStorage     = "FILE_STORAGE"
 	blockStorage    = "BLOCK_STORAGE"
 	retryTime       = 5
+
+	storageAccessAuthoritative = "authoritative"
+	storageAccessAdditive      = "additive"
+
+	snapshotScheduleMask = "active,retentionCount,minute,hour,dayOfWeek,type[keyname]"
 )
 
 var (
@@ -43,6 +51,16 @@ var (
 		10:   "10_IOPS_PER_GB",
 	}
 
+	// Snapshot schedule types and the SoftLayer_Network_Storage_Schedule_Type keyname of each
+	snapshotScheduleTypeMap = map[string]string{
+		"HOURLY": "SNAPSHOT_HOURLY",
+		"DAILY":  "SNAPSHOT_DAILY",
+		"WEEKLY": "SNAPSHOT_WEEKLY",
+	}
+
+	// SoftLayer_Network_Storage_Schedule returns dayOfWeek as a number starting from Sunday
+	snapshotScheduleDays = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
 	// Map IOPS value to endurance storage tier capacityRestrictionMaximum/capacityRestrictionMinimum in SoftLayer_Product_Item
 	enduranceCapacityRestrictionMap = map[float64]int{
 		0.25: 100,
@@ -91,11 +109,14 @@ func resourceSoftLayerFileStorage() *schema.Resource {
 		Exists:   resourceSoftLayerFileStorageExists,
 		Importer: &schema.ResourceImporter{},
 
+		CustomizeDiff: validateStorageOrder(fileStorage),
+
 		Schema: map[string]*schema.Schema{
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageType,
 			},
 
 			"datacenter": {
@@ -107,13 +128,11 @@ func resourceSoftLayerFileStorage() *schema.Resource {
 			"capacity": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"iops": {
 				Type:     schema.TypeFloat,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"volumename": {
@@ -132,6 +151,20 @@ func resourceSoftLayerFileStorage() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"origin_volume_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"origin_snapshot_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
 			"allowed_virtual_guest_ids": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -162,11 +195,27 @@ func resourceSoftLayerFileStorage() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"access_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  storageAccessAuthoritative,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					mode := v.(string)
+					if mode != storageAccessAuthoritative && mode != storageAccessAdditive {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either '%s' or '%s'", k, storageAccessAuthoritative, storageAccessAdditive))
+					}
+					return
+				},
+			},
+
 			"notes": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
 
+			"snapshot_schedule": storageSnapshotScheduleSchema(),
+
 			"mountpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -184,7 +233,13 @@ func resourceSoftLayerFileStorageCreate(d *schema.ResourceData, meta interface{}
 	capacity := d.Get("capacity").(int)
 	snapshotCapacity := d.Get("snapshot_capacity").(int)
 
-	storageOrderContainer, err := buildStorageProductOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, fileStorage, datacenter)
+	var storageOrderContainer datatypes.Container_Product_Order
+	var err error
+	if d.Get("origin_volume_id").(int) > 0 {
+		storageOrderContainer, err = buildStorageAsAServiceOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, fileStorage, datacenter)
+	} else {
+		storageOrderContainer, err = buildStorageProductOrderContainer(sess, storageType, iops, capacity, snapshotCapacity, fileStorage, datacenter)
+	}
 	if err != nil {
 		return fmt.Errorf("Error while creating storage:%s", err)
 	}
@@ -193,13 +248,16 @@ func resourceSoftLayerFileStorageCreate(d *schema.ResourceData, meta interface{}
 
 	var receipt datatypes.Container_Product_Order_Receipt
 
-	switch storageType {
-	case enduranceType:
+	switch {
+	case d.Get("origin_volume_id").(int) > 0:
+		receipt, err = services.GetProductOrderService(sess).PlaceOrder(
+			buildDuplicateStorageOrderContainer(d, storageOrderContainer, nil), sl.Bool(false))
+	case storageType == enduranceType:
 		receipt, err = services.GetProductOrderService(sess).PlaceOrder(
 			&datatypes.Container_Product_Order_Network_Storage_Enterprise{
 				Container_Product_Order: storageOrderContainer,
 			}, sl.Bool(false))
-	case performanceType:
+	case storageType == performanceType:
 		receipt, err = services.GetProductOrderService(sess).PlaceOrder(
 			&datatypes.Container_Product_Order_Network_PerformanceStorage_Nfs{
 				Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
@@ -240,6 +298,18 @@ func resourceSoftLayerFileStorageCreate(d *schema.ResourceData, meta interface{}
 
 	log.Printf("[INFO] Storage ID: %s", d.Id())
 
+	// Wait for the duplication of the origin volume to start and to finish
+	if d.Get("origin_volume_id").(int) > 0 {
+		err = waitForStorageTransactionStart(sess, *fileStorage.Id, 10*time.Minute)
+		if err == nil {
+			_, err = waitForStorageTransactions(sess, *fileStorage.Id)
+		}
+		if err != nil {
+			return fmt.Errorf(
+				"Error waiting for storage (%s) to be duplicated: %s", d.Id(), err)
+		}
+	}
+
 	return resourceSoftLayerFileStorageUpdate(d, meta)
 }
 
@@ -283,28 +353,37 @@ func resourceSoftLayerFileStorageRead(d *schema.ResourceData, meta interface{})
 	// Read allowed_ip_addresses
 	allowedIpaddressesList := make([]string, 0, len(storage.AllowedIpAddresses))
 	for _, allowedIpaddress := range storage.AllowedIpAddresses {
-		allowedIpaddressesList = append(allowedIpaddressesList, *allowedIpaddress.IpAddress)
+		if isManagedStorageAccess(d, "allowed_ip_addresses", *allowedIpaddress.IpAddress) {
+			allowedIpaddressesList = append(allowedIpaddressesList, *allowedIpaddress.IpAddress)
+		}
 	}
 	d.Set("allowed_ip_addresses", allowedIpaddressesList)
 
 	// Read allowed_subnets
 	allowedSubnetsList := make([]string, 0, len(storage.AllowedSubnets))
 	for _, allowedSubnets := range storage.AllowedSubnets {
-		allowedSubnetsList = append(allowedSubnetsList, *allowedSubnets.NetworkIdentifier+"/"+strconv.Itoa(*allowedSubnets.Cidr))
+		allowedSubnet := *allowedSubnets.NetworkIdentifier + "/" + strconv.Itoa(*allowedSubnets.Cidr)
+		if isManagedStorageAccess(d, "allowed_subnets", allowedSubnet) {
+			allowedSubnetsList = append(allowedSubnetsList, allowedSubnet)
+		}
 	}
 	d.Set("allowed_subnets", allowedSubnetsList)
 
 	// Read allowed_virtual_guest_ids
 	allowedVirtualGuestIdsList := make([]int, 0, len(storage.AllowedVirtualGuests))
 	for _, allowedVirtualGuest := range storage.AllowedVirtualGuests {
-		allowedVirtualGuestIdsList = append(allowedVirtualGuestIdsList, *allowedVirtualGuest.Id)
+		if isManagedStorageAccess(d, "allowed_virtual_guest_ids", *allowedVirtualGuest.Id) {
+			allowedVirtualGuestIdsList = append(allowedVirtualGuestIdsList, *allowedVirtualGuest.Id)
+		}
 	}
 	d.Set("allowed_virtual_guest_ids", allowedVirtualGuestIdsList)
 
 	// Read allowed_hardware_ids
 	allowedHardwareIdsList := make([]int, 0, len(storage.AllowedHardware))
 	for _, allowedHW := range storage.AllowedHardware {
-		allowedHardwareIdsList = append(allowedHardwareIdsList, *allowedHW.Id)
+		if isManagedStorageAccess(d, "allowed_hardware_ids", *allowedHW.Id) {
+			allowedHardwareIdsList = append(allowedHardwareIdsList, *allowedHW.Id)
+		}
 	}
 	d.Set("allowed_hardware_ids", allowedHardwareIdsList)
 
@@ -316,6 +395,12 @@ func resourceSoftLayerFileStorageRead(d *schema.ResourceData, meta interface{})
 		d.Set("notes", *storage.Notes)
 	}
 
+	snapshotSchedules, err := getSnapshotSchedules(sess, storageId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage information: %s", err)
+	}
+	d.Set("snapshot_schedule", snapshotSchedules)
+
 	mountpoint, err := services.GetNetworkStorageService(sess).Id(storageId).GetFileNetworkMountAddress()
 	if err != nil {
 		return fmt.Errorf("Error retrieving storage information: %s", err)
@@ -341,6 +426,14 @@ func resourceSoftLayerFileStorageUpdate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Error updating storage information: %s", err)
 	}
 
+	// Modify capacity and iops. Create calls Update, so skip new resources which are already ordered with them.
+	if !d.IsNewResource() && (d.HasChange("capacity") || d.HasChange("iops")) {
+		err := modifyStorage(d, sess, storage)
+		if err != nil {
+			return fmt.Errorf("Error updating storage information: %s", err)
+		}
+	}
+
 	// Update allowed_ip_addresses
 	if d.HasChange("allowed_ip_addresses") {
 		err := updateAllowedIpAddresses(d, sess, storage)
@@ -381,6 +474,14 @@ func resourceSoftLayerFileStorageUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	// Update snapshot_schedule
+	if d.HasChange("snapshot_schedule") {
+		err := updateSnapshotSchedules(d, sess, storage)
+		if err != nil {
+			return fmt.Errorf("Error updating storage information: %s", err)
+		}
+	}
+
 	return resourceSoftLayerFileStorageRead(d, meta)
 }
 
@@ -442,6 +543,11 @@ func buildStorageProductOrderContainer(
 	storageProtocol string,
 	datacenter string) (datatypes.Container_Product_Order, error) {
 
+	if _, ok := storagePackageMap[storageProtocol][storageType]; !ok {
+		return datatypes.Container_Product_Order{},
+			fmt.Errorf("Invalid storage type %s. Available value(s) is(are) %s, %s", storageType, enduranceType, performanceType)
+	}
+
 	// Build product item filters for performance storage
 	iopsKeyName, err := getIopsKeyName(iops, storageType)
 	if err != nil {
@@ -525,8 +631,12 @@ func buildStorageProductOrderContainer(
 	}
 
 	// Lookup the data center ID
-	dc, err := location.GetDatacenterByName(sess, datacenter)
+	err = validatePackageDatacenter(sess, *pkg.Id, storagePackageType, datacenter)
 	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+	dc, err := location.GetDatacenterByName(sess, datacenter)
+	if err != nil || dc.Id == nil {
 		return datatypes.Container_Product_Order{},
 			fmt.Errorf("No data centers matching %s could be found", datacenter)
 	}
@@ -640,7 +750,10 @@ func WaitForStorageAvailable(d *schema.ResourceData, meta interface{}) (interfac
 func getIopsKeyName(iops float64, storageType string) (string, error) {
 	switch storageType {
 	case enduranceType:
-		return enduranceIopsMap[iops], nil
+		if keyName, ok := enduranceIopsMap[iops]; ok {
+			return keyName, nil
+		}
+		return "", fmt.Errorf("Invalid iops %g for Endurance storage. Available value(s) is(are) %s", iops, getEnduranceIopsValues())
 	case performanceType:
 		return fmt.Sprintf("%.f_IOPS", iops), nil
 	}
@@ -651,46 +764,81 @@ func getPrice(productItems []datatypes.Product_Item, keyName string, categoryCod
 	for _, item := range productItems {
 		if strings.HasPrefix(*item.KeyName, keyName) {
 			for _, price := range item.Prices {
-				// When price.LocationGroupId is null, xml-rpc returns <value> <string/> </value> and
-				// softlayer-go returns &0 instead of nil.
-				if *price.Categories[0].CategoryCode == categoryCode && (price.LocationGroupId == nil || *price.LocationGroupId == 0) {
-					if capacityRestrictionType == "STORAGE_SPACE" {
-						if price.CapacityRestrictionMinimum == nil ||
-							price.CapacityRestrictionMaximum == nil {
-							continue
-						}
-						capacityRestrictionMinimum, _ := strconv.Atoi(*price.CapacityRestrictionMinimum)
-						capacityRestrictionMaximum, _ := strconv.Atoi(*price.CapacityRestrictionMaximum)
-						if capacityRestrictionMinimum > 0 &&
-							capacityRestriction >= capacityRestrictionMinimum &&
-							capacityRestriction <= capacityRestrictionMaximum {
-							return price, nil
-						}
-					}
-
-					if capacityRestrictionType == "STORAGE_TIER_LEVEL" {
-						if price.CapacityRestrictionMinimum == nil ||
-							price.CapacityRestrictionMaximum == nil {
-							continue
-						}
-						capacityRestrictionMinimum, _ := strconv.Atoi(*price.CapacityRestrictionMinimum)
-						capacityRestrictionMaximum, _ := strconv.Atoi(*price.CapacityRestrictionMaximum)
-						if capacityRestrictionMinimum > 0 &&
-							capacityRestriction == capacityRestrictionMinimum &&
-							capacityRestriction == capacityRestrictionMaximum {
-							return price, nil
-						}
-					}
-
-					if capacityRestrictionType == "" && capacityRestriction == 0 {
-						return price, nil
-					}
+				if isMatchingStoragePrice(price, categoryCode, capacityRestrictionType, capacityRestriction) {
+					return price, nil
 				}
 			}
 		}
 	}
 	return datatypes.Product_Item_Price{},
-		fmt.Errorf("No product items matching with keyName %s and categoryCode %s could be found", keyName, categoryCode)
+		fmt.Errorf("No product items matching with keyName %s and categoryCode %s could be found. Available item(s) is(are) %s",
+			keyName, categoryCode, strings.Join(getAvailableKeyNames(productItems, categoryCode, capacityRestrictionType, capacityRestriction), ", "))
+}
+
+// Returns true if the price is a standard price of the category which satisfies the capacity restriction.
+func isMatchingStoragePrice(price datatypes.Product_Item_Price, categoryCode string, capacityRestrictionType string, capacityRestriction int) bool {
+	// When price.LocationGroupId is null, xml-rpc returns <value> <string/> </value> and
+	// softlayer-go returns &0 instead of nil.
+	if len(price.Categories) == 0 || *price.Categories[0].CategoryCode != categoryCode ||
+		(price.LocationGroupId != nil && *price.LocationGroupId != 0) {
+		return false
+	}
+
+	switch capacityRestrictionType {
+	case "STORAGE_SPACE":
+		if price.CapacityRestrictionMinimum == nil ||
+			price.CapacityRestrictionMaximum == nil {
+			return false
+		}
+		capacityRestrictionMinimum, _ := strconv.Atoi(*price.CapacityRestrictionMinimum)
+		capacityRestrictionMaximum, _ := strconv.Atoi(*price.CapacityRestrictionMaximum)
+		return capacityRestrictionMinimum > 0 &&
+			capacityRestriction >= capacityRestrictionMinimum &&
+			capacityRestriction <= capacityRestrictionMaximum
+	case "STORAGE_TIER_LEVEL":
+		if price.CapacityRestrictionMinimum == nil ||
+			price.CapacityRestrictionMaximum == nil {
+			return false
+		}
+		capacityRestrictionMinimum, _ := strconv.Atoi(*price.CapacityRestrictionMinimum)
+		capacityRestrictionMaximum, _ := strconv.Atoi(*price.CapacityRestrictionMaximum)
+		return capacityRestrictionMinimum > 0 &&
+			capacityRestriction == capacityRestrictionMinimum &&
+			capacityRestriction == capacityRestrictionMaximum
+	case "":
+		return capacityRestriction == 0
+	}
+	return false
+}
+
+// Returns the key names of product items which have a matching price. It is used to suggest valid values.
+func getAvailableKeyNames(productItems []datatypes.Product_Item, categoryCode string, capacityRestrictionType string, capacityRestriction int) []string {
+	keyNames := make([]string, 0)
+	for _, item := range productItems {
+		for _, price := range item.Prices {
+			if isMatchingStoragePrice(price, categoryCode, capacityRestrictionType, capacityRestriction) {
+				keyNames = append(keyNames, *item.KeyName)
+				break
+			}
+		}
+	}
+	sort.Strings(keyNames)
+	return keyNames
+}
+
+// Returns the supported IOPS per GB values of endurance storage in ascending order.
+func getEnduranceIopsValues() string {
+	iopsValues := make([]float64, 0, len(enduranceIopsMap))
+	for iops := range enduranceIopsMap {
+		iopsValues = append(iopsValues, iops)
+	}
+	sort.Float64s(iopsValues)
+
+	values := make([]string, 0, len(iopsValues))
+	for _, iops := range iopsValues {
+		values = append(values, strconv.FormatFloat(iops, 'g', -1, 64))
+	}
+	return strings.Join(values, ", ")
 }
 
 func getIops(storage datatypes.Network_Storage, storageType string) (float64, error) {
@@ -777,7 +925,7 @@ func updateAllowedIpAddresses(d *schema.ResourceData, sess *session.Session, sto
 				break
 			}
 		}
-		if isDeletedId {
+		if isDeletedId && isManagedStorageAccess(d, "allowed_ip_addresses", *oldAllowedIpAddresses.IpAddress) {
 			for {
 				_, err := services.GetNetworkStorageService(sess).
 					Id(id).
@@ -860,7 +1008,8 @@ func updateAllowedSubnets(d *schema.ResourceData, sess *session.Session, storage
 				break
 			}
 		}
-		if isDeletedSubnet {
+		if isDeletedSubnet && isManagedStorageAccess(d, "allowed_subnets",
+			*oldAllowedSubnets.NetworkIdentifier+"/"+strconv.Itoa(*oldAllowedSubnets.Cidr)) {
 			_, err := services.GetNetworkStorageService(sess).
 				Id(id).
 				RemoveAccessFromHostList([]datatypes.Container_Network_Storage_Host{
@@ -921,7 +1070,7 @@ func updateAllowedVirtualGuestIds(d *schema.ResourceData, sess *session.Session,
 				break
 			}
 		}
-		if isDeletedId {
+		if isDeletedId && isManagedStorageAccess(d, "allowed_virtual_guest_ids", *oldAllowedVirtualGuest.Id) {
 			for {
 				_, err := services.GetNetworkStorageService(sess).
 					Id(id).
@@ -982,7 +1131,7 @@ func updateAllowedHardwareIds(d *schema.ResourceData, sess *session.Session, sto
 				break
 			}
 		}
-		if isDeletedId {
+		if isDeletedId && isManagedStorageAccess(d, "allowed_hardware_ids", *oldAllowedHardware.Id) {
 			_, err := services.GetNetworkStorageService(sess).
 				Id(id).
 				RemoveAccessFromHostList([]datatypes.Container_Network_Storage_Host{
@@ -999,6 +1148,17 @@ func updateAllowedHardwareIds(d *schema.ResourceData, sess *session.Session, sto
 	return nil
 }
 
+// Returns true if the access of the host is managed by the allowed_* argument key. In the additive access mode,
+// hosts authorized outside of the storage resource, for example by softlayer_storage_access, are neither read
+// into the argument nor removed from the storage.
+func isManagedStorageAccess(d *schema.ResourceData, key string, value interface{}) bool {
+	if d.Get("access_mode").(string) != storageAccessAdditive {
+		return true
+	}
+	o, n := d.GetChange(key)
+	return o.(*schema.Set).Contains(value) || n.(*schema.Set).Contains(value)
+}
+
 func updateNotes(d *schema.ResourceData, sess *session.Session, storage datatypes.Network_Storage) error {
 	id := *storage.Id
 	notes := d.Get("notes").(string)
@@ -1014,3 +1174,436 @@ func updateNotes(d *schema.ResourceData, sess *session.Session, storage datatype
 
 	return nil
 }
+
+func storageSnapshotScheduleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 3,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"schedule_type": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						scheduleType := v.(string)
+						if _, ok := snapshotScheduleTypeMap[scheduleType]; !ok {
+							errors = append(errors, fmt.Errorf(
+								"%s should be one of 'HOURLY', 'DAILY' or 'WEEKLY'", k))
+						}
+						return
+					},
+				},
+				"retention_count": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				"minute": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+				"hour": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+				"day_of_week": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "SUNDAY",
+				},
+			},
+		},
+	}
+}
+
+// Returns an error if a schedule type is defined more than once, or if a schedule sets a field which its
+// schedule type doesn't use. Read reports those fields with their default values.
+func validateSnapshotSchedules(schedules []interface{}) error {
+	scheduleTypes := make(map[string]bool)
+	for _, v := range schedules {
+		schedule := v.(map[string]interface{})
+		scheduleType := schedule["schedule_type"].(string)
+		if scheduleTypes[scheduleType] {
+			return fmt.Errorf("The %s snapshot schedule is defined more than once", scheduleType)
+		}
+		scheduleTypes[scheduleType] = true
+
+		if scheduleType == "HOURLY" && schedule["hour"].(int) != 0 {
+			return fmt.Errorf("hour can't be set for HOURLY snapshot schedules")
+		}
+		if scheduleType != "WEEKLY" && schedule["day_of_week"].(string) != "SUNDAY" {
+			return fmt.Errorf("day_of_week can only be set for WEEKLY snapshot schedules")
+		}
+	}
+	return nil
+}
+
+// Returns the active snapshot schedules of the storage. Fields that don't apply to the schedule type
+// are set to their default values to avoid spurious diffs.
+func getSnapshotSchedules(sess *session.Session, storageId int) ([]map[string]interface{}, error) {
+	schedules, err := services.GetNetworkStorageService(sess).
+		Id(storageId).
+		Mask(snapshotScheduleMask).
+		GetSchedules()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotSchedules := make([]map[string]interface{}, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.Active == nil || *schedule.Active != 1 || schedule.Type == nil || schedule.Type.Keyname == nil {
+			continue
+		}
+
+		scheduleType := ""
+		for k, v := range snapshotScheduleTypeMap {
+			if v == *schedule.Type.Keyname {
+				scheduleType = k
+			}
+		}
+		if scheduleType == "" {
+			// Replication schedules are managed by softlayer_storage_replica.
+			continue
+		}
+
+		retentionCount, _ := strconv.Atoi(sl.Get(schedule.RetentionCount, "0").(string))
+		snapshotSchedule := map[string]interface{}{
+			"schedule_type":   scheduleType,
+			"retention_count": retentionCount,
+			"minute":          0,
+			"hour":            0,
+			"day_of_week":     "SUNDAY",
+		}
+
+		snapshotSchedule["minute"], _ = strconv.Atoi(sl.Get(schedule.Minute, "0").(string))
+		if scheduleType != "HOURLY" {
+			snapshotSchedule["hour"], _ = strconv.Atoi(sl.Get(schedule.Hour, "0").(string))
+		}
+		if scheduleType == "WEEKLY" {
+			dayOfWeek := sl.Get(schedule.DayOfWeek, "").(string)
+			if day, err := strconv.Atoi(dayOfWeek); err == nil && day >= 0 && day < len(snapshotScheduleDays) {
+				dayOfWeek = snapshotScheduleDays[day]
+			}
+			snapshotSchedule["day_of_week"] = strings.ToUpper(dayOfWeek)
+		}
+
+		snapshotSchedules = append(snapshotSchedules, snapshotSchedule)
+	}
+
+	return snapshotSchedules, nil
+}
+
+func updateSnapshotSchedules(d *schema.ResourceData, sess *session.Session, storage datatypes.Network_Storage) error {
+	id := *storage.Id
+	o, n := d.GetChange("snapshot_schedule")
+	oldSchedules := o.(*schema.Set)
+	newSchedules := n.(*schema.Set)
+
+	// Disable schedule types which are no longer configured.
+	newScheduleTypes := make(map[string]bool)
+	for _, v := range newSchedules.List() {
+		newScheduleTypes[v.(map[string]interface{})["schedule_type"].(string)] = true
+	}
+	for _, v := range oldSchedules.Difference(newSchedules).List() {
+		scheduleType := v.(map[string]interface{})["schedule_type"].(string)
+		if newScheduleTypes[scheduleType] {
+			continue
+		}
+		_, err := services.GetNetworkStorageService(sess).
+			Id(id).
+			DisableSnapshots(sl.String(scheduleType))
+		if err != nil {
+			return fmt.Errorf("Error disabling %s snapshots of storage (%d): %s", scheduleType, id, err)
+		}
+	}
+
+	// Enabling an already enabled schedule type updates the schedule.
+	for _, v := range newSchedules.Difference(oldSchedules).List() {
+		schedule := v.(map[string]interface{})
+		scheduleType := schedule["schedule_type"].(string)
+		_, err := services.GetNetworkStorageService(sess).
+			Id(id).
+			EnableSnapshots(
+				sl.String(scheduleType),
+				sl.Int(schedule["retention_count"].(int)),
+				sl.Int(schedule["minute"].(int)),
+				sl.Int(schedule["hour"].(int)),
+				sl.String(schedule["day_of_week"].(string)),
+			)
+		if err != nil {
+			return fmt.Errorf("Error enabling %s snapshots of storage (%d): %s", scheduleType, id, err)
+		}
+	}
+
+	return nil
+}
+
+// Builds an order for a volume which starts as a duplicate of origin_volume_id, or of origin_snapshot_id
+// of the origin volume when it is set.
+func buildDuplicateStorageOrderContainer(
+	d *schema.ResourceData,
+	storageOrderContainer datatypes.Container_Product_Order,
+	osFormatType *datatypes.Network_Storage_Iscsi_OS_Type) *datatypes.Container_Product_Order_Network_Storage_AsAService {
+
+	order := &datatypes.Container_Product_Order_Network_Storage_AsAService{
+		Container_Product_Order: storageOrderContainer,
+		DuplicateOriginVolumeId: sl.Int(d.Get("origin_volume_id").(int)),
+		VolumeSize:              sl.Int(d.Get("capacity").(int)),
+		OsFormatType:            osFormatType,
+	}
+
+	if originSnapshotId := d.Get("origin_snapshot_id").(int); originSnapshotId > 0 {
+		order.DuplicateOriginSnapshotId = sl.Int(originSnapshotId)
+	}
+
+	if d.Get("type").(string) == performanceType {
+		order.Iops = sl.Int(int(d.Get("iops").(float64)))
+	}
+
+	return order
+}
+
+// Container_Product_Order_Network_Storage_AsAService_Upgrade is not available in the vendored softlayer-go.
+// PlaceOrder sets the complexType from the type name, so the name must match the SoftLayer data type.
+type Container_Product_Order_Network_Storage_AsAService_Upgrade struct {
+	datatypes.Container_Product_Order_Network_Storage_AsAService
+
+	// The volume being modified
+	Volume *datatypes.Network_Storage `json:"volume,omitempty" xmlrpc:"volume,omitempty"`
+}
+
+// Places a modification order to change the capacity and iops of the storage, and waits until the storage is modified.
+func modifyStorage(d *schema.ResourceData, sess *session.Session, storage datatypes.Network_Storage) error {
+	id := *storage.Id
+	storageType := d.Get("type").(string)
+	capacity := d.Get("capacity").(int)
+	iops := d.Get("iops").(float64)
+
+	order, err := buildStorageUpgradeOrderContainer(sess, storageType, iops, capacity, *storage.CapacityGb)
+	if err != nil {
+		return err
+	}
+	order.Volume = &datatypes.Network_Storage{Id: sl.Int(id)}
+
+	log.Printf("[INFO] Modifying storage (%d) to %d GB and %g IOPS", id, capacity, iops)
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error modifying storage (%d): %s", id, err)
+	}
+
+	_, err = waitForStorageModification(sess, id, storageType, capacity, iops)
+	if err != nil {
+		return fmt.Errorf("Error waiting for storage (%d) to be modified: %s", id, err)
+	}
+
+	return nil
+}
+
+// Builds a modification order from the storage as a service package. Storage capacity can't be decreased.
+func buildStorageUpgradeOrderContainer(
+	sess *session.Session,
+	storageType string,
+	iops float64,
+	capacity int,
+	currentCapacity int) (Container_Product_Order_Network_Storage_AsAService_Upgrade, error) {
+
+	if capacity < currentCapacity {
+		return Container_Product_Order_Network_Storage_AsAService_Upgrade{},
+			fmt.Errorf("Storage capacity can't be decreased from %d GB to %d GB", currentCapacity, capacity)
+	}
+
+	pkg, err := product.GetPackageByType(sess, storageAsAServicePackageType)
+	if err != nil {
+		return Container_Product_Order_Network_Storage_AsAService_Upgrade{}, err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id, rangeItemMask)
+	if err != nil {
+		return Container_Product_Order_Network_Storage_AsAService_Upgrade{}, err
+	}
+
+	targetItemPrices, err := getStorageAsAServicePrices(productItems, storageType, iops, capacity)
+	if err != nil {
+		return Container_Product_Order_Network_Storage_AsAService_Upgrade{}, err
+	}
+
+	order := Container_Product_Order_Network_Storage_AsAService_Upgrade{}
+	if storageType == performanceType {
+		order.Iops = sl.Int(int(iops))
+	}
+
+	order.PackageId = pkg.Id
+	order.Prices = targetItemPrices
+	order.Quantity = sl.Int(1)
+	order.VolumeSize = sl.Int(capacity)
+
+	return order, nil
+}
+
+// Returns the storage as a service prices of the storage type, IOPS and capacity, without a storage protocol price.
+func getStorageAsAServicePrices(productItems []datatypes.Product_Item, storageType string, iops float64, capacity int) ([]datatypes.Product_Item_Price, error) {
+	iopsKeyName, err := getIopsKeyName(iops, storageType)
+	if err != nil {
+		return nil, err
+	}
+
+	servicePrice, err := getPrice(productItems, "", "storage_as_a_service", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	targetItemPrices := []datatypes.Product_Item_Price{servicePrice}
+
+	switch storageType {
+	case enduranceType:
+		tierPrice, err := getPrice(productItems, iopsKeyName, "storage_tier_level", "", 0)
+		if err != nil {
+			return nil, err
+		}
+		spacePrice, err := getRangePrice(productItems, capacity, "performance_storage_space", "STORAGE_TIER_LEVEL", enduranceCapacityRestrictionMap[iops])
+		if err != nil {
+			return nil, err
+		}
+		targetItemPrices = append(targetItemPrices, tierPrice, spacePrice)
+	case performanceType:
+		spacePrice, err := getRangePrice(productItems, capacity, "performance_storage_space", "", 0)
+		if err != nil {
+			return nil, err
+		}
+		iopsPrice, err := getRangePrice(productItems, int(iops), "performance_storage_iops", "STORAGE_SPACE", capacity)
+		if err != nil {
+			return nil, err
+		}
+		targetItemPrices = append(targetItemPrices, spacePrice, iopsPrice)
+	default:
+		return nil, fmt.Errorf("Invalid storage type %s", storageType)
+	}
+
+	return targetItemPrices, nil
+}
+
+// Builds an order from the storage as a service package. Duplicate volumes can only be ordered from this package,
+// so the prices of the enterprise and performance packages can't be used for them.
+func buildStorageAsAServiceOrderContainer(
+	sess *session.Session,
+	storageType string,
+	iops float64,
+	capacity int,
+	snapshotCapacity int,
+	storageProtocol string,
+	datacenter string) (datatypes.Container_Product_Order, error) {
+
+	if _, ok := storagePackageMap[storageProtocol][storageType]; !ok {
+		return datatypes.Container_Product_Order{},
+			fmt.Errorf("Invalid storage type %s. Available value(s) is(are) %s, %s", storageType, enduranceType, performanceType)
+	}
+
+	pkg, err := product.GetPackageByType(sess, storageAsAServicePackageType)
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id, rangeItemMask)
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+
+	targetItemPrices, err := getStorageAsAServicePrices(productItems, storageType, iops, capacity)
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+
+	// The storage as a service package sells file and block storage with the protocol items of endurance storage.
+	storageProtocolPrice, err := getPrice(productItems, "", storagePackageMap[storageProtocol][enduranceType]["storageProtocolCategoryCode"], "", 0)
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+	targetItemPrices = append(targetItemPrices, storageProtocolPrice)
+
+	if storageType == enduranceType && snapshotCapacity > 0 {
+		snapshotCapacityPrice, err := getRangePrice(productItems, snapshotCapacity, "storage_snapshot_space", "STORAGE_TIER_LEVEL", enduranceCapacityRestrictionMap[iops])
+		if err != nil {
+			return datatypes.Container_Product_Order{}, err
+		}
+		targetItemPrices = append(targetItemPrices, snapshotCapacityPrice)
+	}
+
+	err = validatePackageDatacenter(sess, *pkg.Id, storageAsAServicePackageType, datacenter)
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+	dc, err := location.GetDatacenterByName(sess, datacenter)
+	if err != nil || dc.Id == nil {
+		return datatypes.Container_Product_Order{},
+			fmt.Errorf("No data centers matching %s could be found", datacenter)
+	}
+
+	return datatypes.Container_Product_Order{
+		PackageId: pkg.Id,
+		Location:  sl.String(strconv.Itoa(*dc.Id)),
+		Prices:    targetItemPrices,
+		Quantity:  sl.Int(1),
+	}, nil
+}
+
+// Returns a price of the item whose capacity range includes the value. Storage as a service items are sold by capacity range.
+func getRangePrice(productItems []datatypes.Product_Item, value int, categoryCode string, capacityRestrictionType string, capacityRestriction int) (datatypes.Product_Item_Price, error) {
+	for _, item := range productItems {
+		if item.CapacityMinimum == nil || item.CapacityMaximum == nil {
+			continue
+		}
+		capacityMinimum, _ := strconv.Atoi(*item.CapacityMinimum)
+		capacityMaximum, _ := strconv.Atoi(*item.CapacityMaximum)
+		if value < capacityMinimum || value > capacityMaximum {
+			continue
+		}
+		for _, price := range item.Prices {
+			if isMatchingStoragePrice(price, categoryCode, capacityRestrictionType, capacityRestriction) {
+				return price, nil
+			}
+		}
+	}
+	return datatypes.Product_Item_Price{},
+		fmt.Errorf("No product items of categoryCode %s for %d could be found. Available item(s) is(are) %s",
+			categoryCode, value, strings.Join(getAvailableKeyNames(productItems, categoryCode, capacityRestrictionType, capacityRestriction), ", "))
+}
+
+// Waits until the storage has no active transactions and has the target capacity and iops.
+func waitForStorageModification(sess *session.Session, id int, storageType string, capacity int, iops float64) (interface{}, error) {
+	log.Printf("Waiting for storage (%d) to be modified.", id)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "modifying"},
+		Target:  []string{"modified"},
+		Refresh: func() (interface{}, string, error) {
+			storage, err := services.GetNetworkStorageService(sess).
+				Id(id).
+				Mask(storageDetailMask + ",activeTransactions").
+				GetObject()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Error retrieving storage: %s", err)
+				}
+				return false, "retry", nil
+			}
+
+			if len(storage.ActiveTransactions) > 0 || storage.CapacityGb == nil || *storage.CapacityGb != capacity {
+				return storage, "modifying", nil
+			}
+
+			currentIops, err := getIops(storage, storageType)
+			if err != nil || currentIops != iops {
+				return storage, "modifying", nil
+			}
+
+			return storage, "modified", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
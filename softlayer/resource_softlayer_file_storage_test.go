@@ -2,7 +2,9 @@ package softlayer
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -56,6 +58,7 @@ func TestAccSoftLayerFileStorage_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("softlayer_file_storage.fs_endurance", "allowed_subnets.#", "1"),
 					resource.TestCheckResourceAttr("softlayer_file_storage.fs_endurance", "allowed_ip_addresses.#", "1"),
 					resource.TestCheckResourceAttr("softlayer_file_storage.fs_endurance", "notes", "updated endurance notes"),
+					resource.TestCheckResourceAttr("softlayer_file_storage.fs_endurance", "snapshot_schedule.#", "2"),
 					// Performance Storage
 					resource.TestCheckResourceAttr("softlayer_file_storage.fs_performance", "allowed_virtual_guest_ids.#", "1"),
 					resource.TestCheckResourceAttr("softlayer_file_storage.fs_performance", "allowed_subnets.#", "1"),
@@ -67,6 +70,108 @@ func TestAccSoftLayerFileStorage_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerFileStorage_InvalidOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccCheckSoftLayerFileStorageConfig_invalidIops,
+				ExpectError: regexp.MustCompile("Invalid iops 3 for Endurance storage"),
+			},
+			resource.TestStep{
+				Config:      testAccCheckSoftLayerFileStorageConfig_invalidCapacity,
+				ExpectError: regexp.MustCompile("No product items matching with keyName 21_GB_"),
+			},
+			resource.TestStep{
+				Config:      testAccCheckSoftLayerFileStorageConfig_snapshotWithoutOrigin,
+				ExpectError: regexp.MustCompile("origin_snapshot_id can only be set together with origin_volume_id"),
+			},
+		},
+	})
+}
+
+func TestValidateSnapshotSchedules(t *testing.T) {
+	schedule := func(scheduleType string, hour int, dayOfWeek string) interface{} {
+		return map[string]interface{}{
+			"schedule_type":   scheduleType,
+			"retention_count": 7,
+			"minute":          30,
+			"hour":            hour,
+			"day_of_week":     dayOfWeek,
+		}
+	}
+
+	testCases := []struct {
+		schedules []interface{}
+		error     string
+	}{
+		{[]interface{}{schedule("HOURLY", 0, "SUNDAY"), schedule("DAILY", 2, "SUNDAY"), schedule("WEEKLY", 2, "MONDAY")}, ""},
+		{[]interface{}{schedule("DAILY", 2, "SUNDAY"), schedule("DAILY", 3, "SUNDAY")}, "defined more than once"},
+		{[]interface{}{schedule("HOURLY", 5, "SUNDAY")}, "hour can't be set"},
+		{[]interface{}{schedule("DAILY", 2, "MONDAY")}, "day_of_week can only be set"},
+	}
+
+	for _, tc := range testCases {
+		err := validateSnapshotSchedules(tc.schedules)
+		if tc.error == "" && err != nil {
+			t.Errorf("Expected schedules %v to be valid, got: %s", tc.schedules, err)
+		}
+		if tc.error != "" && (err == nil || !strings.Contains(err.Error(), tc.error)) {
+			t.Errorf("Expected schedules %v to fail with %q, got: %v", tc.schedules, tc.error, err)
+		}
+	}
+}
+
+func TestAccSoftLayerFileStorage_Modify(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerFileStorageConfig_modify, 20, 2.0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerFileStorageExists("softlayer_file_storage.fs_modify"),
+					resource.TestCheckResourceAttr("softlayer_file_storage.fs_modify", "capacity", "20"),
+					resource.TestCheckResourceAttr("softlayer_file_storage.fs_modify", "iops", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerFileStorageConfig_modify, 40, 4.0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerFileStorageExists("softlayer_file_storage.fs_modify"),
+					resource.TestCheckResourceAttr("softlayer_file_storage.fs_modify", "capacity", "40"),
+					resource.TestCheckResourceAttr("softlayer_file_storage.fs_modify", "iops", "4"),
+				),
+			},
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckSoftLayerFileStorageConfig_modify, 20, 4.0),
+				ExpectError: regexp.MustCompile("Storage capacity can't be decreased from 40 GB to 20 GB"),
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerFileStorage_Duplicate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFileStorageConfig_duplicate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerFileStorageExists("softlayer_file_storage.fs_origin"),
+					testAccCheckSoftLayerFileStorageExists("softlayer_file_storage.fs_duplicate"),
+					testAccCheckSoftLayerResources("softlayer_file_storage.fs_duplicate", "origin_volume_id",
+						"softlayer_file_storage.fs_origin", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_duplicate", "capacity", "40"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSoftLayerFileStorageExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -153,6 +258,18 @@ resource "softlayer_file_storage" "fs_endurance" {
         allowed_ip_addresses = [ "${softlayer_virtual_guest.storagevm1.ipv4_address_private}" ]
         snapshot_capacity = 10
         notes = "updated endurance notes"
+        snapshot_schedule {
+            schedule_type = "HOURLY"
+            retention_count = 5
+            minute = 30
+        }
+        snapshot_schedule {
+            schedule_type = "WEEKLY"
+            retention_count = 2
+            minute = 0
+            hour = 2
+            day_of_week = "SATURDAY"
+        }
 }
 
 resource "softlayer_file_storage" "fs_performance" {
@@ -165,3 +282,59 @@ resource "softlayer_file_storage" "fs_performance" {
         allowed_ip_addresses = [ "${softlayer_virtual_guest.storagevm1.ipv4_address_private}" ]
 }
 `
+
+const testAccCheckSoftLayerFileStorageConfig_invalidIops = `
+resource "softlayer_file_storage" "fs_endurance" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 3
+}
+`
+
+const testAccCheckSoftLayerFileStorageConfig_invalidCapacity = `
+resource "softlayer_file_storage" "fs_performance" {
+        type = "Performance"
+        datacenter = "dal06"
+        capacity = 21
+        iops = 100
+}
+`
+
+const testAccCheckSoftLayerFileStorageConfig_snapshotWithoutOrigin = `
+resource "softlayer_file_storage" "fs_snapshot" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 0.25
+        origin_snapshot_id = 12345
+}
+`
+
+const testAccCheckSoftLayerFileStorageConfig_modify = `
+resource "softlayer_file_storage" "fs_modify" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = %d
+        iops = %g
+}
+`
+
+const testAccCheckSoftLayerFileStorageConfig_duplicate = `
+resource "softlayer_file_storage" "fs_origin" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 2
+        snapshot_capacity = 10
+}
+
+resource "softlayer_file_storage" "fs_duplicate" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 40
+        iops = 2
+        snapshot_capacity = 10
+        origin_volume_id = "${softlayer_file_storage.fs_origin.id}"
+}
+`
@@ -0,0 +1,302 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	fwHardwareMask = "id,status,guestNetworkComponent[guestId,maxSpeed],networkComponent[hardwareId,maxSpeed]"
+)
+
+// Statuses of a provisioned firewall, which either filters the traffic of the server or is bypassed.
+var fwHardwareActiveStatuses = []string{"no_edit", "bypass"}
+
+func resourceSoftLayerFwHardware() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerFwHardwareCreate,
+		Read:     resourceSoftLayerFwHardwareRead,
+		Delete:   resourceSoftLayerFwHardwareDelete,
+		Exists:   resourceSoftLayerFwHardwareExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_guest_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"hardware_id"},
+			},
+
+			"hardware_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id"},
+			},
+
+			"port_speed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerFwHardwareCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	guestId := d.Get("virtual_guest_id").(int)
+	hardwareId := d.Get("hardware_id").(int)
+	if guestId == 0 && hardwareId == 0 {
+		return fmt.Errorf("One of virtual_guest_id or hardware_id must be set")
+	}
+
+	// The firewall is ordered for the port speed of the public network interface of the server.
+	var portSpeed int
+	if guestId != 0 {
+		guest, err := services.GetVirtualGuestService(sess).
+			Id(guestId).
+			Mask("primaryNetworkComponent[maxSpeed]").
+			GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving virtual guest %d: %s", guestId, err)
+		}
+		if guest.PrimaryNetworkComponent == nil || guest.PrimaryNetworkComponent.MaxSpeed == nil {
+			return fmt.Errorf("Virtual guest %d doesn't have a public network interface", guestId)
+		}
+		portSpeed = *guest.PrimaryNetworkComponent.MaxSpeed
+	} else {
+		hardware, err := services.GetHardwareService(sess).
+			Id(hardwareId).
+			Mask("primaryNetworkComponent[maxSpeed]").
+			GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving bare metal server %d: %s", hardwareId, err)
+		}
+		if hardware.PrimaryNetworkComponent == nil || hardware.PrimaryNetworkComponent.MaxSpeed == nil {
+			return fmt.Errorf("Bare metal server %d doesn't have a public network interface", hardwareId)
+		}
+		portSpeed = *hardware.PrimaryNetworkComponent.MaxSpeed
+	}
+
+	pkg, err := product.GetPackageByType(sess, FwHardwareDedicatedPackageType)
+	if err != nil {
+		return err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return err
+	}
+
+	// Per-server firewall items have key names like FIREWALL_10_MBPS, and their capacity is the port speed.
+	targetItems := []datatypes.Product_Item{}
+	for _, item := range productItems {
+		if item.KeyName == nil || item.Capacity == nil {
+			continue
+		}
+		if strings.HasPrefix(*item.KeyName, "FIREWALL_") && strings.HasSuffix(*item.KeyName, "_MBPS") &&
+			int(*item.Capacity) == portSpeed {
+			targetItems = append(targetItems, item)
+		}
+	}
+
+	if len(targetItems) == 0 {
+		return fmt.Errorf("No hardware firewall product items for a port speed of %d Mbps could be found", portSpeed)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Protection_Firewall{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: targetItems[0].Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+	}
+	if guestId != 0 {
+		productOrderContainer.VirtualGuests = []datatypes.Virtual_Guest{{Id: sl.Int(guestId)}}
+	} else {
+		productOrderContainer.Hardware = []datatypes.Hardware{{Id: sl.Int(hardwareId)}}
+	}
+
+	log.Println("[INFO] Creating hardware firewall")
+
+	_, err = services.GetProductOrderService(sess).
+		PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of hardware firewall: %s", err)
+	}
+
+	fw, err := waitForHardwareFirewall(sess, guestId, hardwareId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of hardware firewall: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(*fw.Id))
+	d.Set("port_speed", portSpeed)
+
+	log.Printf("[INFO] Firewall ID: %s", d.Id())
+
+	return resourceSoftLayerFwHardwareRead(d, meta)
+}
+
+func resourceSoftLayerFwHardwareRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	fw, err := services.GetNetworkComponentFirewallService(sess).
+		Id(fwId).
+		Mask(fwHardwareMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving firewall information: %s", err)
+	}
+
+	if fw.GuestNetworkComponent != nil {
+		if fw.GuestNetworkComponent.GuestId != nil {
+			d.Set("virtual_guest_id", *fw.GuestNetworkComponent.GuestId)
+		}
+		d.Set("port_speed", sl.Get(fw.GuestNetworkComponent.MaxSpeed, nil))
+	}
+	if fw.NetworkComponent != nil {
+		if fw.NetworkComponent.HardwareId != nil {
+			d.Set("hardware_id", *fw.NetworkComponent.HardwareId)
+		}
+		d.Set("port_speed", sl.Get(fw.NetworkComponent.MaxSpeed, nil))
+	}
+	d.Set("status", sl.Get(fw.Status, nil))
+
+	return nil
+}
+
+func resourceSoftLayerFwHardwareDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	fwService := services.GetNetworkComponentFirewallService(sess)
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	// Get billing item associated with the firewall
+	billingItem, err := fwService.Id(fwId).GetBillingItem()
+
+	if err != nil {
+		return fmt.Errorf("Error while looking up billing item associated with the firewall: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error while looking up billing item associated with the firewall: No billing item for ID:%d", fwId)
+	}
+
+	success, err := services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful cancellation")
+	}
+
+	return nil
+}
+
+func resourceSoftLayerFwHardwareExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	fw, err := services.GetNetworkComponentFirewallService(sess).
+		Id(fwId).
+		Mask("id").
+		GetObject()
+
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving firewall information: %s", err)
+	}
+
+	return fw.Id != nil && *fw.Id == fwId, nil
+}
+
+// Waits until the ordered firewall is provisioned for the virtual guest or bare metal server, and returns it.
+func waitForHardwareFirewall(sess *session.Session, guestId int, hardwareId int) (datatypes.Network_Component_Firewall, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			var fw *datatypes.Network_Component_Firewall
+			if guestId != 0 {
+				guest, err := services.GetVirtualGuestService(sess).
+					Id(guestId).
+					Mask("firewallServiceComponent[id,status]").
+					GetObject()
+				if err != nil {
+					return nil, "", err
+				}
+				fw = guest.FirewallServiceComponent
+			} else {
+				hardware, err := services.GetHardwareService(sess).
+					Id(hardwareId).
+					Mask("firewallServiceComponent[id,status]").
+					GetObject()
+				if err != nil {
+					return nil, "", err
+				}
+				fw = hardware.FirewallServiceComponent
+			}
+
+			if fw == nil || fw.Id == nil {
+				return datatypes.Network_Component_Firewall{}, "pending", nil
+			}
+
+			status := sl.Get(fw.Status, "").(string)
+			log.Printf("[INFO] Hardware firewall %d status: %s", *fw.Id, status)
+			for _, activeStatus := range fwHardwareActiveStatuses {
+				if status == activeStatus {
+					return *fw, "complete", nil
+				}
+			}
+			return *fw, "pending", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+	if err != nil {
+		return datatypes.Network_Component_Firewall{}, err
+	}
+
+	return pendingResult.(datatypes.Network_Component_Firewall), nil
+}
@@ -3,12 +3,14 @@ package softlayer
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/services"
@@ -17,17 +19,29 @@ import (
 )
 
 const (
-	aclMask = "name,firewallInterfaces[name,firewallContextAccessControlLists]"
+	aclMask = "name,firewallInterfaces[name,firewallContextAccessControlLists[id,direction]]"
+
+	fwRuleMask = "orderValue,action,sourceIpAddress,sourceIpCidr,sourceIpSubnetMask,destinationIpAddress," +
+		"destinationIpCidr,destinationIpSubnetMask,destinationPortRangeStart,destinationPortRangeEnd,protocol,notes,version"
+
+	// Notes of the any-open rules which replace the rules of the firewall when all rules are removed.
+	anyOpenRuleNotesPrefix = "terraform-default-anyopen-"
+
+	fwInterfaceOutside = "outside"
+	fwInterfaceInside  = "inside"
 )
 
 func resourceSoftLayerFwHardwareDedicatedRules() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceSoftLayerFwHardwareDedicatedRulesCreate,
-		Read:     resourceSoftLayerFwHardwareDedicatedRulesRead,
-		Update:   resourceSoftLayerFwHardwareDedicatedRulesUpdate,
-		Delete:   resourceSoftLayerFwHardwareDedicatedRulesDelete,
-		Exists:   resourceSoftLayerFwHardwareDedicatedRulesExists,
-		Importer: &schema.ResourceImporter{},
+		Create:        resourceSoftLayerFwHardwareDedicatedRulesCreate,
+		Read:          resourceSoftLayerFwHardwareDedicatedRulesRead,
+		Update:        resourceSoftLayerFwHardwareDedicatedRulesUpdate,
+		Delete:        resourceSoftLayerFwHardwareDedicatedRulesDelete,
+		Exists:        resourceSoftLayerFwHardwareDedicatedRulesExists,
+		CustomizeDiff: validateFirewallRules,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerFwHardwareDedicatedRulesImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"firewall_id": {
@@ -36,80 +50,259 @@ func resourceSoftLayerFwHardwareDedicatedRules() *schema.Resource {
 				ForceNew: true,
 			},
 
-			"rules": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"action": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"src_ip_address": {
-							Type:     schema.TypeString,
-							Required: true,
-							DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
-								newSrcIpAddress := net.ParseIP(n)
-								return newSrcIpAddress != nil && (newSrcIpAddress.String() == net.ParseIP(o).String())
-							},
-						},
-						"src_ip_cidr": {
-							Type:     schema.TypeInt,
-							Required: true,
-						},
-						"dst_ip_address": {
-							Type:     schema.TypeString,
-							Required: true,
-							DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
-								newDstIpAddress := net.ParseIP(n)
-								return newDstIpAddress != nil && (newDstIpAddress.String() == net.ParseIP(o).String())
-							},
-						},
-						"dst_ip_cidr": {
-							Type:     schema.TypeInt,
-							Required: true,
-						},
-						// ICMP, GRE, AH, and ESP don't require port ranges.
-						"dst_port_range_start": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-						"dst_port_range_end": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"notes": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+			"interface": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  fwInterfaceOutside,
+				// State written before the interface could be chosen has no interface, and is for the outside one.
+				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+					return o == "" && n == fwInterfaceOutside
+				},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					fwInterface := v.(string)
+					if fwInterface != fwInterfaceOutside && fwInterface != fwInterfaceInside {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either '%s' or '%s'", k, fwInterfaceOutside, fwInterfaceInside))
+					}
+					return
+				},
+			},
+
+			"rules": fwRulesSchema(),
+
+			"wait_time_minutes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  30,
+			},
+		},
+	}
+}
+
+// Returns the schema of the rules of a hardware firewall. The rules of dedicated and per-server hardware
+// firewalls are configured in the same way.
+func fwRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"action": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						action := v.(string)
+						if action != "permit" && action != "deny" {
+							errors = append(errors, fmt.Errorf("%s should be either 'permit' or 'deny'", k))
+						}
+						return
+					},
+				},
+				// Required when both addresses are any. Otherwise the IP version is the version of the addresses.
+				"ip_version": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						version := v.(int)
+						if version != 4 && version != 6 {
+							errors = append(errors, fmt.Errorf("%s should be either 4 or 6", k))
+						}
+						return
+					},
+				},
+				"src_ip_address": {
+					Type:     schema.TypeString,
+					Required: true,
+					DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+						newSrcIpAddress := net.ParseIP(n)
+						return newSrcIpAddress != nil && (newSrcIpAddress.String() == net.ParseIP(o).String())
+					},
+				},
+				"src_ip_cidr": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				"dst_ip_address": {
+					Type:     schema.TypeString,
+					Required: true,
+					DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+						newDstIpAddress := net.ParseIP(n)
+						return newDstIpAddress != nil && (newDstIpAddress.String() == net.ParseIP(o).String())
+					},
+				},
+				"dst_ip_cidr": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				// ICMP, GRE, AH, and ESP don't require port ranges.
+				"dst_port_range_start": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"dst_port_range_end": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"protocol": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						protocol := v.(string)
+						for _, p := range []string{"tcp", "udp", "icmp", "gre", "pptp", "ah", "esp"} {
+							if protocol == p {
+								return
+							}
+						}
+						errors = append(errors, fmt.Errorf(
+							"%s should be one of 'tcp', 'udp', 'icmp', 'gre', 'pptp', 'ah' or 'esp'", k))
+						return
 					},
 				},
+				"notes": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
 			},
 		},
 	}
 }
 
+// validateFirewallRules checks the rules during plan so that rules which the firewall device would reject
+// are reported before an update request is submitted.
+func validateFirewallRules(d *schema.ResourceDiff, meta interface{}) error {
+	for i, ruleItem := range d.Get("rules").([]interface{}) {
+		ruleMap, ok := ruleItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		known := func(key string) bool {
+			return d.NewValueKnown(fmt.Sprintf("rules.%d.%s", i, key))
+		}
+		if err := validateFirewallRule(ruleMap, known); err != nil {
+			return fmt.Errorf("Invalid firewall rule %d: %s", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Returns an error if the addresses, CIDRs or port range of the rule are not valid for its IP version and
+// protocol. Values which are not known until apply are not checked.
+func validateFirewallRule(rule map[string]interface{}, known func(key string) bool) error {
+	if known("ip_version") && known("src_ip_address") && known("dst_ip_address") &&
+		rule["ip_version"].(int) == 0 && !firewallRuleHasAddress(rule) {
+		return fmt.Errorf("ip_version is required when src_ip_address and dst_ip_address are both 'any'")
+	}
+
+	version := firewallRuleConfigVersion(rule)
+	maxCidr := 32
+	if version == 6 {
+		maxCidr = 128
+	}
+
+	for _, prefix := range []string{"src", "dst"} {
+		addressKey := prefix + "_ip_address"
+		if known(addressKey) && rule[addressKey].(string) != "any" {
+			address := rule[addressKey].(string)
+			ip := net.ParseIP(address)
+			if ip == nil {
+				return fmt.Errorf("%s is not a valid IP address: %s", addressKey, address)
+			}
+			if (ip.To4() != nil) != (version == 4) {
+				return fmt.Errorf("%s is not an IPv%d address: %s", addressKey, version, address)
+			}
+		}
+
+		cidrKey := prefix + "_ip_cidr"
+		if known(cidrKey) {
+			cidr := rule[cidrKey].(int)
+			if cidr < 0 || cidr > maxCidr {
+				return fmt.Errorf("%s must be between 0 and %d for IPv%d rules: %d", cidrKey, maxCidr, version, cidr)
+			}
+		}
+	}
+
+	if !known("protocol") || !known("dst_port_range_start") || !known("dst_port_range_end") {
+		return nil
+	}
+
+	protocol := rule["protocol"].(string)
+	start := rule["dst_port_range_start"].(int)
+	end := rule["dst_port_range_end"].(int)
+
+	switch protocol {
+	case "tcp", "udp":
+		if start == 0 || end == 0 {
+			return fmt.Errorf("dst_port_range_start and dst_port_range_end are required for %s rules", protocol)
+		}
+	case "icmp", "gre", "ah", "esp":
+		if start != 0 || end != 0 {
+			return fmt.Errorf("dst_port_range_start and dst_port_range_end are not allowed for %s rules", protocol)
+		}
+	}
+
+	if start == 0 && end == 0 {
+		return nil
+	}
+	if start < 1 || start > 65535 || end < 1 || end > 65535 {
+		return fmt.Errorf("dst_port_range_start and dst_port_range_end must be between 1 and 65535")
+	}
+	if start > end {
+		return fmt.Errorf("dst_port_range_start %d is greater than dst_port_range_end %d", start, end)
+	}
+
+	return nil
+}
+
+// Returns the IP version of a configured rule, which is its ip_version, or the version of its addresses if
+// ip_version is not set. Rules whose addresses are both any must set ip_version.
+func firewallRuleConfigVersion(rule map[string]interface{}) int {
+	if version, ok := rule["ip_version"].(int); ok && version != 0 {
+		return version
+	}
+	for _, key := range []string{"src_ip_address", "dst_ip_address"} {
+		address, _ := rule[key].(string)
+		if ip := net.ParseIP(address); ip != nil {
+			if ip.To4() == nil {
+				return 6
+			}
+			return 4
+		}
+	}
+	return 4
+}
+
+// Returns true if the source or destination of the rule is an IP address, which determines the IP version.
+func firewallRuleHasAddress(rule map[string]interface{}) bool {
+	for _, key := range []string{"src_ip_address", "dst_ip_address"} {
+		address, _ := rule[key].(string)
+		if net.ParseIP(address) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the configured rules of both IP versions. An update request replaces all rules of the access
+// control list, so the rules of both versions are submitted together.
 func prepareRules(d *schema.ResourceData) []datatypes.Network_Firewall_Update_Request_Rule {
 	ruleList := d.Get("rules").([]interface{})
 	rules := make([]datatypes.Network_Firewall_Update_Request_Rule, 0)
-	for i, ruleItem := range ruleList {
+	for _, ruleItem := range ruleList {
 		ruleMap := ruleItem.(map[string]interface{})
 		var rule datatypes.Network_Firewall_Update_Request_Rule
-		rule.OrderValue = sl.Int(i + 1)
+		rule.OrderValue = sl.Int(len(rules) + 1)
+		rule.Version = sl.Int(firewallRuleConfigVersion(ruleMap))
 		rule.Action = sl.String(ruleMap["action"].(string))
 		rule.SourceIpAddress = sl.String(ruleMap["src_ip_address"].(string))
 		rule.SourceIpCidr = sl.Int(ruleMap["src_ip_cidr"].(int))
 		rule.DestinationIpAddress = sl.String(ruleMap["dst_ip_address"].(string))
 		rule.DestinationIpCidr = sl.Int(ruleMap["dst_ip_cidr"].(int))
 
-		if ruleMap["dst_port_range_start"] != nil {
+		if ruleMap["dst_port_range_start"].(int) > 0 {
 			rule.DestinationPortRangeStart = sl.Int(ruleMap["dst_port_range_start"].(int))
 		}
-		if ruleMap["dst_port_range_end"] != nil {
+		if ruleMap["dst_port_range_end"].(int) > 0 {
 			rule.DestinationPortRangeEnd = sl.Int(ruleMap["dst_port_range_end"].(int))
 		}
 
@@ -118,15 +311,14 @@ func prepareRules(d *schema.ResourceData) []datatypes.Network_Firewall_Update_Re
 			rule.Notes = sl.String(ruleMap["notes"].(string))
 		}
 
-		if strings.Contains(*rule.SourceIpAddress, ":") || strings.Contains(*rule.DestinationIpAddress, ":") {
-			rule.Version = sl.Int(6)
-		}
 		rules = append(rules, rule)
 	}
 	return rules
 }
 
-func getFirewallContextAccessControlListId(fwId int, sess *session.Session) (int, error) {
+// Returns the ID of the inbound access control list of the firewall interface. Rules of both IP versions
+// are applied to this access control list.
+func getFirewallContextAccessControlListId(fwId int, fwInterface string, sess *session.Session) (int, error) {
 	service := services.GetNetworkVlanFirewallService(sess)
 	vlan, err := service.Id(fwId).Mask(aclMask).GetNetworkVlans()
 
@@ -134,44 +326,187 @@ func getFirewallContextAccessControlListId(fwId int, sess *session.Session) (int
 		return 0, err
 	}
 
-	for _, fwInterface := range vlan[0].FirewallInterfaces {
-		if fwInterface.Name != nil &&
-			*fwInterface.Name == "outside" &&
-			len(fwInterface.FirewallContextAccessControlLists) > 0 &&
-			fwInterface.FirewallContextAccessControlLists[0].Id != nil {
-			return *fwInterface.FirewallContextAccessControlLists[0].Id, nil
+	if len(vlan) == 0 {
+		return 0, fmt.Errorf("No VLAN is protected by firewall %d", fwId)
+	}
+
+	for _, fwInterfaceItem := range vlan[0].FirewallInterfaces {
+		if fwInterfaceItem.Name == nil || *fwInterfaceItem.Name != fwInterface {
+			continue
+		}
+		for _, acl := range fwInterfaceItem.FirewallContextAccessControlLists {
+			if acl.Id != nil && acl.Direction != nil && *acl.Direction == "in" {
+				return *acl.Id, nil
+			}
+		}
+		if len(fwInterfaceItem.FirewallContextAccessControlLists) > 0 &&
+			fwInterfaceItem.FirewallContextAccessControlLists[0].Id != nil {
+			return *fwInterfaceItem.FirewallContextAccessControlLists[0].Id, nil
+		}
+	}
+	return 0, fmt.Errorf("No access control list found for the %s interface of firewall %d", fwInterface, fwId)
+}
+
+// Returns the interface of the firewall which the rules are applied to. Imported resources use the
+// outside interface.
+func getFirewallInterface(d *schema.ResourceData) string {
+	if fwInterface, ok := d.GetOk("interface"); ok {
+		return fwInterface.(string)
+	}
+	return fwInterfaceOutside
+}
+
+// Returns the rules of the firewall in their order. The any-open rules which replace the rules of the
+// firewall when the resource is destroyed are not returned. The IP version of a rule is only kept if it is
+// configured, or if the addresses of the rule don't determine it.
+func flattenFirewallRules(d *schema.ResourceData, fwRules []datatypes.Network_Vlan_Firewall_Rule) []map[string]interface{} {
+	configured, _ := d.Get("rules").([]interface{})
+
+	rules := make([]map[string]interface{}, 0, len(fwRules))
+	for _, rule := range sortFirewallRules(fwRules) {
+		if isAnyOpenRule(rule) {
+			continue
+		}
+		r := flattenFirewallRule(rule)
+		configuredVersion := 0
+		if len(rules) < len(configured) {
+			if ruleMap, ok := configured[len(rules)].(map[string]interface{}); ok {
+				configuredVersion, _ = ruleMap["ip_version"].(int)
+			}
+		}
+		if configuredVersion == 0 && firewallRuleHasAddress(r) {
+			delete(r, "ip_version")
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// Returns the properties of a firewall rule. Rules which were created outside of Terraform may use a subnet
+// mask instead of a CIDR, and may omit addresses which match any address.
+func flattenFirewallRule(rule datatypes.Network_Vlan_Firewall_Rule) map[string]interface{} {
+	r := make(map[string]interface{})
+	r["action"] = sl.Get(rule.Action, "")
+	r["ip_version"] = firewallRuleVersion(rule)
+	r["src_ip_address"] = sl.Get(rule.SourceIpAddress, "any")
+	r["src_ip_cidr"] = firewallRuleCidr(rule.SourceIpCidr, rule.SourceIpSubnetMask)
+	r["dst_ip_address"] = sl.Get(rule.DestinationIpAddress, "any")
+	r["dst_ip_cidr"] = firewallRuleCidr(rule.DestinationIpCidr, rule.DestinationIpSubnetMask)
+	if rule.DestinationPortRangeStart != nil {
+		r["dst_port_range_start"] = *rule.DestinationPortRangeStart
+	}
+	if rule.DestinationPortRangeEnd != nil {
+		r["dst_port_range_end"] = *rule.DestinationPortRangeEnd
+	}
+	r["protocol"] = sl.Get(rule.Protocol, "")
+	if rule.Notes != nil && len(*rule.Notes) > 0 {
+		r["notes"] = *rule.Notes
+	}
+	return r
+}
+
+// Returns the CIDR of a rule address, or the prefix length of its subnet mask if the CIDR is not set.
+func firewallRuleCidr(cidr *int, subnetMask *string) int {
+	if cidr != nil {
+		return *cidr
+	}
+	if subnetMask != nil {
+		if mask := net.ParseIP(*subnetMask); mask != nil && mask.To4() != nil {
+			ones, _ := net.IPMask(mask.To4()).Size()
+			return ones
+		}
+	}
+	return 0
+}
+
+// Returns true if the rule is one of the any-open rules added by appendAnyOpenRule.
+func isAnyOpenRule(rule datatypes.Network_Vlan_Firewall_Rule) bool {
+	return rule.Notes != nil && strings.HasPrefix(*rule.Notes, anyOpenRuleNotesPrefix)
+}
+
+// Imports the rules of a dedicated firewall. The ID is the firewall ID, optionally followed by the interface,
+// for example 1234 or 1234:inside.
+func resourceSoftLayerFwHardwareDedicatedRulesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("Not a valid ID, must be <firewall ID> or <firewall ID>:<interface>: %s", d.Id())
+	}
+
+	fwId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	fwInterface := fwInterfaceOutside
+	if len(parts) == 2 {
+		fwInterface = parts[1]
+		if fwInterface != fwInterfaceOutside && fwInterface != fwInterfaceInside {
+			return nil, fmt.Errorf("interface should be either '%s' or '%s': %s",
+				fwInterfaceOutside, fwInterfaceInside, fwInterface)
 		}
 	}
-	return 0, fmt.Errorf("No firewallContextAccessControlListId.")
+
+	d.SetId(strconv.Itoa(fwId))
+	d.Set("interface", fwInterface)
+	d.Set("wait_time_minutes", 30)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// Submits a firewall update request with the rules of both IP versions, and waits until it is applied. The
+// request replaces all rules of the firewall. The template identifies the access control list or the
+// per-server firewall which the rules are applied to, and getRules returns the rules which it contains.
+func applyFirewallRules(sess *session.Session, template datatypes.Network_Firewall_Update_Request,
+	rules []datatypes.Network_Firewall_Update_Request_Rule, timeoutMinutes int,
+	getRules func() ([]datatypes.Network_Vlan_Firewall_Rule, error)) error {
+
+	template.Rules = rules
+
+	request, err := services.GetNetworkFirewallUpdateRequestService(sess).CreateObject(&template)
+	if err != nil {
+		return fmt.Errorf("Error submitting firewall rules: %s", err)
+	}
+	if request.Id == nil {
+		return fmt.Errorf("Error submitting firewall rules: SoftLayer returned no firewall update request ID")
+	}
+
+	return waitForFirewallUpdateRequest(sess, *request.Id, rules, timeoutMinutes, getRules)
+}
+
+// Returns a function which retrieves the rules of the access control list.
+func getAccessControlListRules(sess *session.Session, aclId int) func() ([]datatypes.Network_Vlan_Firewall_Rule, error) {
+	return func() ([]datatypes.Network_Vlan_Firewall_Rule, error) {
+		return services.GetNetworkFirewallAccessControlListService(sess).
+			Id(aclId).
+			Mask(fwRuleMask).
+			GetRules()
+	}
 }
 
 func resourceSoftLayerFwHardwareDedicatedRulesCreate(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(ProviderConfig).SoftLayerSession()
 	fwId := d.Get("firewall_id").(int)
-	rules := prepareRules(d)
 
-	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, sess)
+	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, getFirewallInterface(d), sess)
 	if err != nil {
 		return fmt.Errorf("Error during creation of dedicated hardware firewall rules: %s", err)
 	}
 
-	ruleTemplate := datatypes.Network_Firewall_Update_Request{
+	template := datatypes.Network_Firewall_Update_Request{
 		FirewallContextAccessControlListId: sl.Int(fwContextACLId),
-		Rules: rules,
 	}
 
 	log.Println("[INFO] Creating dedicated hardware firewall rules")
 
-	_, err = services.GetNetworkFirewallUpdateRequestService(sess).CreateObject(&ruleTemplate)
-	if err != nil {
-		return fmt.Errorf("Error during creation of dedicated hardware firewall rules: %s", err)
-	}
-
 	d.SetId(strconv.Itoa(fwId))
 
 	log.Printf("[INFO] Firewall rules ID: %s", d.Id())
-	log.Printf("[INFO] Wait one minute for applying the rules.")
-	time.Sleep(time.Minute)
+
+	err = applyFirewallRules(sess, template, prepareRules(d), d.Get("wait_time_minutes").(int),
+		getAccessControlListRules(sess, fwContextACLId))
+	if err != nil {
+		return fmt.Errorf("Error during creation of dedicated hardware firewall rules: %s", err)
+	}
 
 	return resourceSoftLayerFwHardwareDedicatedRulesRead(d, meta)
 }
@@ -179,57 +514,58 @@ func resourceSoftLayerFwHardwareDedicatedRulesCreate(d *schema.ResourceData, met
 func resourceSoftLayerFwHardwareDedicatedRulesRead(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(ProviderConfig).SoftLayerSession()
 
-	fwRulesID, _ := strconv.Atoi(d.Id())
+	fwRulesID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
 
-	fw, err := services.GetNetworkVlanFirewallService(sess).
-		Id(fwRulesID).
-		Mask("rules").
-		GetObject()
+	fwInterface := getFirewallInterface(d)
 
+	fwContextACLId, err := getFirewallContextAccessControlListId(fwRulesID, fwInterface, sess)
 	if err != nil {
 		return fmt.Errorf("Error retrieving firewall rules: %s", err)
 	}
 
-	rules := make([]map[string]interface{}, 0, len(fw.Rules))
-	for _, rule := range fw.Rules {
-		r := make(map[string]interface{})
-		r["action"] = *rule.Action
-		r["src_ip_address"] = *rule.SourceIpAddress
-		r["src_ip_cidr"] = *rule.SourceIpCidr
-		r["dst_ip_address"] = *rule.DestinationIpAddress
-		r["dst_ip_cidr"] = *rule.DestinationIpCidr
-		if rule.DestinationPortRangeStart != nil {
-			r["dst_port_range_start"] = *rule.DestinationPortRangeStart
-		}
-		if rule.DestinationPortRangeEnd != nil {
-			r["dst_port_range_end"] = *rule.DestinationPortRangeEnd
-		}
-		r["protocol"] = *rule.Protocol
-		if len(*rule.Notes) > 0 {
-			r["notes"] = *rule.Notes
-		}
-		rules = append(rules, r)
+	fwRules, err := getAccessControlListRules(sess, fwContextACLId)()
+	if err != nil {
+		return fmt.Errorf("Error retrieving firewall rules: %s", err)
 	}
 
 	d.Set("firewall_id", fwRulesID)
-	d.Set("rules", rules)
+	d.Set("interface", fwInterface)
+	d.Set("rules", flattenFirewallRules(d, fwRules))
 
 	return nil
 }
 
-func appendAnyOpenRule(rules []datatypes.Network_Firewall_Update_Request_Rule, protocol string) []datatypes.Network_Firewall_Update_Request_Rule {
-	ruleAnyOpen := datatypes.Network_Firewall_Update_Request_Rule{
-		OrderValue:                sl.Int(len(rules) + 1),
-		Action:                    sl.String("permit"),
-		SourceIpAddress:           sl.String("any"),
-		DestinationIpAddress:      sl.String("any"),
-		DestinationPortRangeStart: sl.Int(1),
-		DestinationPortRangeEnd:   sl.Int(65535),
-		Protocol:                  sl.String(protocol),
-		Notes:                     sl.String("terraform-default-anyopen-" + protocol),
+// Returns the IP version of a firewall rule. Rules without a version are IPv4 rules.
+func firewallRuleVersion(rule datatypes.Network_Vlan_Firewall_Rule) int {
+	return sl.Get(rule.Version, 4).(int)
+}
+
+// Orders the rules of the firewall by their order value. Rules of both IP versions are numbered together,
+// but rules which were created outside of Terraform may share an order value, and IPv4 rules come first then.
+func sortFirewallRules(fwRules []datatypes.Network_Vlan_Firewall_Rule) []datatypes.Network_Vlan_Firewall_Rule {
+	sorted := make([]datatypes.Network_Vlan_Firewall_Rule, len(fwRules))
+	copy(sorted, fwRules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		orderI := sl.Get(sorted[i].OrderValue, 0).(int)
+		orderJ := sl.Get(sorted[j].OrderValue, 0).(int)
+		if orderI != orderJ {
+			return orderI < orderJ
+		}
+		return firewallRuleVersion(sorted[i]) < firewallRuleVersion(sorted[j])
+	})
+	return sorted
+}
+
+func appendAnyOpenRule(rules []datatypes.Network_Firewall_Update_Request_Rule, protocol string, version int) []datatypes.Network_Firewall_Update_Request_Rule {
+	notes := anyOpenRuleNotesPrefix + protocol
+	if version == 6 {
+		notes += "-ipv6"
 	}
 
-	ruleAnyOpenIpv6 := datatypes.Network_Firewall_Update_Request_Rule{
+	ruleAnyOpen := datatypes.Network_Firewall_Update_Request_Rule{
 		OrderValue:                sl.Int(len(rules) + 1),
 		Action:                    sl.String("permit"),
 		SourceIpAddress:           sl.String("any"),
@@ -237,11 +573,23 @@ func appendAnyOpenRule(rules []datatypes.Network_Firewall_Update_Request_Rule, p
 		DestinationPortRangeStart: sl.Int(1),
 		DestinationPortRangeEnd:   sl.Int(65535),
 		Protocol:                  sl.String(protocol),
-		Notes:                     sl.String("terraform-default-anyopen-" + protocol + "-ipv6"),
-		Version:                   sl.Int(6),
+		Notes:                     sl.String(notes),
+		Version:                   sl.Int(version),
 	}
 
-	return append(rules, ruleAnyOpen, ruleAnyOpenIpv6)
+	return append(rules, ruleAnyOpen)
+}
+
+// Returns the rules which permit any traffic of both IP versions. They replace the rules of the firewall
+// when the resource is destroyed, because a firewall must have at least one rule.
+func anyOpenRules() []datatypes.Network_Firewall_Update_Request_Rule {
+	rules := make([]datatypes.Network_Firewall_Update_Request_Rule, 0)
+	for _, version := range []int{4, 6} {
+		for _, protocol := range []string{"tcp", "udp", "icmp", "gre", "pptp", "ah", "esp"} {
+			rules = appendAnyOpenRule(rules, protocol, version)
+		}
+	}
+	return rules
 }
 
 func resourceSoftLayerFwHardwareDedicatedRulesUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -250,25 +598,27 @@ func resourceSoftLayerFwHardwareDedicatedRulesUpdate(d *schema.ResourceData, met
 	if err != nil {
 		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
 	}
-	rules := prepareRules(d)
 
-	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, sess)
+	if !d.HasChange("rules") {
+		return resourceSoftLayerFwHardwareDedicatedRulesRead(d, meta)
+	}
+
+	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, getFirewallInterface(d), sess)
 	if err != nil {
 		return fmt.Errorf("Error during updating of dedicated hardware firewall rules: %s", err)
 	}
 
-	ruleTemplate := datatypes.Network_Firewall_Update_Request{
+	template := datatypes.Network_Firewall_Update_Request{
 		FirewallContextAccessControlListId: sl.Int(fwContextACLId),
-		Rules: rules,
 	}
 
 	log.Println("[INFO] Updating dedicated hardware firewall rules")
 
-	_, err = services.GetNetworkFirewallUpdateRequestService(sess).CreateObject(&ruleTemplate)
+	err = applyFirewallRules(sess, template, prepareRules(d), d.Get("wait_time_minutes").(int),
+		getAccessControlListRules(sess, fwContextACLId))
 	if err != nil {
 		return fmt.Errorf("Error during updating of dedicated hardware firewall rules: %s", err)
 	}
-	time.Sleep(time.Minute)
 
 	return resourceSoftLayerFwHardwareDedicatedRulesRead(d, meta)
 }
@@ -280,30 +630,22 @@ func resourceSoftLayerFwHardwareDedicatedRulesDelete(d *schema.ResourceData, met
 		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
 	}
 
-	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, sess)
+	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, getFirewallInterface(d), sess)
 	if err != nil {
 		return fmt.Errorf("Error during deleting of dedicated hardware firewall rules: %s", err)
 	}
 
-	ruleTemplate := datatypes.Network_Firewall_Update_Request{
+	template := datatypes.Network_Firewall_Update_Request{
 		FirewallContextAccessControlListId: sl.Int(fwContextACLId),
 	}
 
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "tcp")
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "udp")
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "icmp")
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "gre")
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "pptp")
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "ah")
-	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "esp")
-
 	log.Println("[INFO] Deleting dedicated hardware firewall rules")
 
-	_, err = services.GetNetworkFirewallUpdateRequestService(sess).CreateObject(&ruleTemplate)
+	err = applyFirewallRules(sess, template, anyOpenRules(), d.Get("wait_time_minutes").(int),
+		getAccessControlListRules(sess, fwContextACLId))
 	if err != nil {
 		return fmt.Errorf("Error during deleting of dedicated hardware firewall rules: %s", err)
 	}
-	time.Sleep(time.Minute)
 
 	return nil
 }
@@ -316,10 +658,20 @@ func resourceSoftLayerFwHardwareDedicatedRulesExists(d *schema.ResourceData, met
 		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
 	}
 
-	fw, err := services.GetNetworkVlanFirewallService(sess).
-		Id(fwRulesID).
-		Mask("rules").
-		GetObject()
+	fwContextACLId, err := getFirewallContextAccessControlListId(fwRulesID, getFirewallInterface(d), sess)
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok {
+			if apiErr.StatusCode == 404 {
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("Error retrieving firewall rules: %s", err)
+	}
+
+	fwRules, err := services.GetNetworkFirewallAccessControlListService(sess).
+		Id(fwContextACLId).
+		Mask("id").
+		GetRules()
 
 	if err != nil {
 		if apiErr, ok := err.(sl.Error); ok {
@@ -330,9 +682,93 @@ func resourceSoftLayerFwHardwareDedicatedRulesExists(d *schema.ResourceData, met
 		return false, fmt.Errorf("Error retrieving firewall rules: %s", err)
 	}
 
-	if len(fw.Rules) == 0 {
+	if len(fwRules) == 0 {
 		return false, nil
 	}
 
 	return true, nil
 }
+
+// Waits until the firewall update request is applied to the firewall device, and checks that the rules
+// returned by getRules are the requested rules. A request which is rejected by the device is removed, and
+// the rules of the firewall are left unchanged.
+func waitForFirewallUpdateRequest(sess *session.Session, requestId int,
+	rules []datatypes.Network_Firewall_Update_Request_Rule, timeoutMinutes int,
+	getRules func() ([]datatypes.Network_Vlan_Firewall_Rule, error)) error {
+
+	log.Printf("[INFO] Waiting for firewall update request %d to be applied", requestId)
+
+	started := time.Now()
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "pending"},
+		Target:  []string{"applied"},
+		Refresh: func() (interface{}, string, error) {
+			request, err := services.GetNetworkFirewallUpdateRequestService(sess).
+				Id(requestId).
+				Mask("id,createDate,applyDate").
+				GetObject()
+			if err != nil {
+				// SoftLayer removes a rejected update request without recording why it was
+				// rejected, so the API error is the only detail which can be reported.
+				if apiErr, ok := err.(sl.Error); ok {
+					if apiErr.StatusCode == 404 {
+						return nil, "", fmt.Errorf(
+							"Firewall update request %d was rejected by the firewall (%s). SoftLayer does not report the reason "+
+								"of the rejection; check the firewall in the SoftLayer portal or open a ticket", requestId, apiErr.Message)
+					}
+					if apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+						return nil, "", fmt.Errorf("Firewall update request %d failed: %s", requestId, apiErr.Message)
+					}
+				}
+				log.Printf("[DEBUG] Error retrieving firewall update request %d, retrying: %s", requestId, err)
+				return nil, "retry", nil
+			}
+
+			if request.ApplyDate == nil {
+				log.Printf("[INFO] Firewall update request %d is pending (%s elapsed)",
+					requestId, time.Since(started).Round(time.Second))
+				return request, "pending", nil
+			}
+
+			log.Printf("[INFO] Firewall update request %d was applied at %s", requestId, request.ApplyDate)
+			return request, "applied", nil
+		},
+		Timeout:    time.Duration(timeoutMinutes) * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	aclRules, err := getRules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving firewall rules: %s", err)
+	}
+
+	if len(aclRules) != len(rules) {
+		return fmt.Errorf("Firewall update request %d was applied, but the firewall has %d rules instead of %d",
+			requestId, len(aclRules), len(rules))
+	}
+
+	for _, rule := range rules {
+		matched := false
+		for _, fwRule := range aclRules {
+			if sl.Get(fwRule.OrderValue, 0) == sl.Get(rule.OrderValue, 0) &&
+				firewallRuleVersion(fwRule) == sl.Get(rule.Version, 4).(int) &&
+				strings.EqualFold(sl.Get(fwRule.Action, "").(string), sl.Get(rule.Action, "").(string)) &&
+				strings.EqualFold(sl.Get(fwRule.Protocol, "").(string), sl.Get(rule.Protocol, "").(string)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("Firewall update request %d was applied, but rule %d of the firewall doesn't match the request",
+				requestId, *rule.OrderValue)
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,12 @@
 package softlayer
 
 import (
-	"github.com/hashicorp/terraform/helper/resource"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
 )
 
 func TestAccSoftLayerFwHardwareDedicatedRules_Basic(t *testing.T) {
@@ -13,8 +17,12 @@ func TestAccSoftLayerFwHardwareDedicatedRules_Basic(t *testing.T) {
 			resource.TestStep{
 				Config: testAccCheckSoftLayerFwHardwareDedicatedRules_basic,
 				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_dedicated_rules.rules", "interface", "outside"),
 					resource.TestCheckResourceAttr(
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.0.action", "deny"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_dedicated_rules.rules", "rules.0.ip_version", "4"),
 					resource.TestCheckResourceAttr(
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.0.src_ip_address", "0.0.0.0"),
 					resource.TestCheckResourceAttr(
@@ -45,6 +53,8 @@ func TestAccSoftLayerFwHardwareDedicatedRules_Basic(t *testing.T) {
 
 					resource.TestCheckResourceAttr(
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.2.action", "permit"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_dedicated_rules.rules", "rules.2.ip_version", "6"),
 					resource.TestCheckResourceAttr(
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.2.src_ip_address",
 						"0000:0000:0000:0000:0000:0000:0000:0000"),
@@ -78,6 +88,8 @@ func TestAccSoftLayerFwHardwareDedicatedRules_Basic(t *testing.T) {
 
 					resource.TestCheckResourceAttr(
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.1.action", "deny"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_dedicated_rules.rules", "rules.1.ip_version", "6"),
 					resource.TestCheckResourceAttr(
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.1.src_ip_address", "2401:c900:1501:0032:0000:0000:0000:0000"),
 					resource.TestCheckResourceAttr(
@@ -90,10 +102,135 @@ func TestAccSoftLayerFwHardwareDedicatedRules_Basic(t *testing.T) {
 						"softlayer_fw_hardware_dedicated_rules.rules", "rules.1.protocol", "udp"),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "softlayer_fw_hardware_dedicated_rules.rules",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
+func TestValidateFirewallRule(t *testing.T) {
+	known := func(key string) bool { return true }
+	rule := func(version int, src string, srcCidr int, protocol string, start int, end int) map[string]interface{} {
+		return map[string]interface{}{
+			"ip_version":           version,
+			"src_ip_address":       src,
+			"src_ip_cidr":          srcCidr,
+			"dst_ip_address":       "any",
+			"dst_ip_cidr":          0,
+			"protocol":             protocol,
+			"dst_port_range_start": start,
+			"dst_port_range_end":   end,
+		}
+	}
+
+	testCases := []struct {
+		rule  map[string]interface{}
+		error string
+	}{
+		{rule(4, "10.1.1.0", 24, "tcp", 80, 80), ""},
+		{rule(6, "2401:c900:1501:32::", 64, "udp", 1, 65535), ""},
+		{rule(4, "any", 0, "icmp", 0, 0), ""},
+		{rule(4, "10.1.1.0", 24, "pptp", 0, 0), ""},
+		{rule(4, "10.1.1.0", 33, "tcp", 80, 80), "src_ip_cidr must be between 0 and 32"},
+		{rule(6, "10.1.1.0", 24, "tcp", 80, 80), "not an IPv6 address"},
+		{rule(4, "0::", 0, "tcp", 80, 80), "not an IPv4 address"},
+		{rule(4, "10.1.1", 24, "tcp", 80, 80), "not a valid IP address"},
+		{rule(4, "10.1.1.0", 24, "udp", 0, 0), "are required for udp rules"},
+		{rule(4, "10.1.1.0", 24, "esp", 1, 65535), "are not allowed for esp rules"},
+		{rule(4, "10.1.1.0", 24, "tcp", 443, 80), "is greater than dst_port_range_end"},
+		{rule(4, "10.1.1.0", 24, "tcp", 1, 65536), "must be between 1 and 65535"},
+		{rule(0, "2401:c900:1501:32::", 64, "tcp", 80, 80), ""},
+		{rule(0, "10.1.1.0", 64, "tcp", 80, 80), "src_ip_cidr must be between 0 and 32"},
+		{rule(0, "any", 0, "tcp", 80, 80), "ip_version is required"},
+	}
+
+	for _, tc := range testCases {
+		err := validateFirewallRule(tc.rule, known)
+		if tc.error == "" && err != nil {
+			t.Errorf("Expected rule %v to be valid, got: %s", tc.rule, err)
+		}
+		if tc.error != "" && (err == nil || !strings.Contains(err.Error(), tc.error)) {
+			t.Errorf("Expected rule %v to fail with %q, got: %v", tc.rule, tc.error, err)
+		}
+	}
+
+	unknownPorts := func(key string) bool { return !strings.HasPrefix(key, "dst_port_range") }
+	if err := validateFirewallRule(rule(4, "10.1.1.0", 24, "tcp", 0, 0), unknownPorts); err != nil {
+		t.Errorf("Expected unknown port range to be skipped, got: %s", err)
+	}
+}
+
+func TestFirewallRuleConfigVersion(t *testing.T) {
+	testCases := []struct {
+		rule    map[string]interface{}
+		version int
+	}{
+		{map[string]interface{}{"ip_version": 6, "src_ip_address": "any", "dst_ip_address": "any"}, 6},
+		{map[string]interface{}{"ip_version": 0, "src_ip_address": "0::", "dst_ip_address": "any"}, 6},
+		{map[string]interface{}{"ip_version": 0, "src_ip_address": "any", "dst_ip_address": "2401:c900:1501:32::"}, 6},
+		{map[string]interface{}{"ip_version": 0, "src_ip_address": "10.1.1.0", "dst_ip_address": "any"}, 4},
+	}
+
+	for _, tc := range testCases {
+		if version := firewallRuleConfigVersion(tc.rule); version != tc.version {
+			t.Errorf("Expected IP version %d for rule %v, got %d", tc.version, tc.rule, version)
+		}
+	}
+}
+
+func TestFirewallRuleCidr(t *testing.T) {
+	if cidr := firewallRuleCidr(sl.Int(24), sl.String("255.255.255.255")); cidr != 24 {
+		t.Errorf("Expected the CIDR to be preferred over the subnet mask, got %d", cidr)
+	}
+	if cidr := firewallRuleCidr(nil, sl.String("255.255.255.0")); cidr != 24 {
+		t.Errorf("Expected CIDR 24 for subnet mask 255.255.255.0, got %d", cidr)
+	}
+	if cidr := firewallRuleCidr(nil, nil); cidr != 0 {
+		t.Errorf("Expected CIDR 0 without a subnet mask, got %d", cidr)
+	}
+}
+
+func TestIsAnyOpenRule(t *testing.T) {
+	for _, rule := range anyOpenRules() {
+		fwRule := datatypes.Network_Vlan_Firewall_Rule{Notes: rule.Notes}
+		if !isAnyOpenRule(fwRule) {
+			t.Errorf("Expected rule with notes %s to be an any-open rule", *rule.Notes)
+		}
+	}
+	if isAnyOpenRule(datatypes.Network_Vlan_Firewall_Rule{Notes: sl.String("Allow SSH")}) {
+		t.Errorf("Expected rule with notes 'Allow SSH' not to be an any-open rule")
+	}
+	if isAnyOpenRule(datatypes.Network_Vlan_Firewall_Rule{}) {
+		t.Errorf("Expected rule without notes not to be an any-open rule")
+	}
+}
+
+func TestSortFirewallRules(t *testing.T) {
+	fwRule := func(orderValue int, version int) datatypes.Network_Vlan_Firewall_Rule {
+		return datatypes.Network_Vlan_Firewall_Rule{OrderValue: sl.Int(orderValue), Version: sl.Int(version)}
+	}
+
+	fwRules := []datatypes.Network_Vlan_Firewall_Rule{
+		fwRule(3, 4), fwRule(1, 6), fwRule(4, 6), fwRule(2, 4), fwRule(4, 4),
+	}
+
+	sorted := sortFirewallRules(fwRules)
+
+	expected := [][2]int{{1, 6}, {2, 4}, {3, 4}, {4, 4}, {4, 6}}
+	if len(sorted) != len(expected) {
+		t.Fatalf("Expected %d rules, got %d", len(expected), len(sorted))
+	}
+	for i, e := range expected {
+		if *sorted[i].OrderValue != e[0] || *sorted[i].Version != e[1] {
+			t.Errorf("Expected rule %d to be IPv%d rule %d, got IPv%d rule %d",
+				i, e[1], e[0], *sorted[i].Version, *sorted[i].OrderValue)
+		}
+	}
+}
+
 const testAccCheckSoftLayerFwHardwareDedicatedRules_basic = `
 resource "softlayer_virtual_guest" "fwvm2" {
     hostname = "fwvm2"
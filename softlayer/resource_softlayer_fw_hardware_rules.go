@@ -0,0 +1,185 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerFwHardwareRules() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceSoftLayerFwHardwareRulesCreate,
+		Read:          resourceSoftLayerFwHardwareRulesRead,
+		Update:        resourceSoftLayerFwHardwareRulesUpdate,
+		Delete:        resourceSoftLayerFwHardwareRulesDelete,
+		Exists:        resourceSoftLayerFwHardwareRulesExists,
+		CustomizeDiff: validateFirewallRules,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerFwHardwareRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"firewall_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rules": fwRulesSchema(),
+
+			"wait_time_minutes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  30,
+			},
+		},
+	}
+}
+
+// Returns a function which retrieves the rules of the per-server firewall.
+func getHardwareFirewallRules(sess *session.Session, fwId int) func() ([]datatypes.Network_Vlan_Firewall_Rule, error) {
+	return func() ([]datatypes.Network_Vlan_Firewall_Rule, error) {
+		componentRules, err := services.GetNetworkComponentFirewallService(sess).
+			Id(fwId).
+			Mask(fwRuleMask).
+			GetRules()
+		if err != nil {
+			return nil, err
+		}
+
+		// The rules of per-server and dedicated firewalls have the same properties.
+		fwRules := make([]datatypes.Network_Vlan_Firewall_Rule, 0, len(componentRules))
+		for _, rule := range componentRules {
+			fwRules = append(fwRules, datatypes.Network_Vlan_Firewall_Rule(rule))
+		}
+		return fwRules, nil
+	}
+}
+
+// Imports the rules of a per-server firewall. The ID is the firewall ID.
+func resourceSoftLayerFwHardwareRulesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	d.Set("wait_time_minutes", 30)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSoftLayerFwHardwareRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	fwId := d.Get("firewall_id").(int)
+
+	template := datatypes.Network_Firewall_Update_Request{
+		NetworkComponentFirewallId: sl.Int(fwId),
+	}
+
+	log.Println("[INFO] Creating hardware firewall rules")
+
+	d.SetId(strconv.Itoa(fwId))
+
+	log.Printf("[INFO] Firewall rules ID: %s", d.Id())
+
+	err := applyFirewallRules(sess, template, prepareRules(d), d.Get("wait_time_minutes").(int),
+		getHardwareFirewallRules(sess, fwId))
+	if err != nil {
+		return fmt.Errorf("Error during creation of hardware firewall rules: %s", err)
+	}
+
+	return resourceSoftLayerFwHardwareRulesRead(d, meta)
+}
+
+func resourceSoftLayerFwHardwareRulesRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	fwRules, err := getHardwareFirewallRules(sess, fwId)()
+	if err != nil {
+		return fmt.Errorf("Error retrieving firewall rules: %s", err)
+	}
+
+	d.Set("firewall_id", fwId)
+	d.Set("rules", flattenFirewallRules(d, fwRules))
+
+	return nil
+}
+
+func resourceSoftLayerFwHardwareRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
+	}
+
+	if !d.HasChange("rules") {
+		return resourceSoftLayerFwHardwareRulesRead(d, meta)
+	}
+
+	template := datatypes.Network_Firewall_Update_Request{
+		NetworkComponentFirewallId: sl.Int(fwId),
+	}
+
+	log.Println("[INFO] Updating hardware firewall rules")
+
+	err = applyFirewallRules(sess, template, prepareRules(d), d.Get("wait_time_minutes").(int),
+		getHardwareFirewallRules(sess, fwId))
+	if err != nil {
+		return fmt.Errorf("Error during updating of hardware firewall rules: %s", err)
+	}
+
+	return resourceSoftLayerFwHardwareRulesRead(d, meta)
+}
+
+func resourceSoftLayerFwHardwareRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
+	}
+
+	template := datatypes.Network_Firewall_Update_Request{
+		NetworkComponentFirewallId: sl.Int(fwId),
+	}
+
+	log.Println("[INFO] Deleting hardware firewall rules")
+
+	err = applyFirewallRules(sess, template, anyOpenRules(), d.Get("wait_time_minutes").(int),
+		getHardwareFirewallRules(sess, fwId))
+	if err != nil {
+		return fmt.Errorf("Error during deleting of hardware firewall rules: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerFwHardwareRulesExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	fwId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	fwRules, err := getHardwareFirewallRules(sess, fwId)()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving firewall rules: %s", err)
+	}
+
+	return len(fwRules) > 0, nil
+}
@@ -0,0 +1,127 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerFwHardwareRules_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFwHardwareRules_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources("softlayer_fw_hardware_rules.rules", "firewall_id",
+						"softlayer_fw_hardware.accfw4", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.#", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.0.action", "permit"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.0.dst_port_range_start", "22"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.0.protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.1.action", "permit"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.1.protocol", "icmp"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFwHardwareRules_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.#", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.0.dst_port_range_start", "443"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.1.ip_version", "6"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware_rules.rules", "rules.1.notes", "Allow HTTPS for IPv6"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "softlayer_fw_hardware_rules.rules",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerFwHardwareRules_guest = `
+resource "softlayer_virtual_guest" "fwvm4" {
+    hostname = "fwvm4"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "sjc01"
+    network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_fw_hardware" "accfw4" {
+  virtual_guest_id = "${softlayer_virtual_guest.fwvm4.id}"
+}
+`
+
+const testAccCheckSoftLayerFwHardwareRules_basic = testAccCheckSoftLayerFwHardwareRules_guest + `
+resource "softlayer_fw_hardware_rules" "rules" {
+ firewall_id = "${softlayer_fw_hardware.accfw4.id}"
+ rules = {
+      "action" = "permit"
+      "src_ip_address"= "0.0.0.0"
+      "src_ip_cidr"= 0
+      "dst_ip_address"= "any"
+      "dst_ip_cidr"= 32
+      "dst_port_range_start"= 22
+      "dst_port_range_end"= 22
+      "notes"= "Allow SSH"
+      "protocol"= "tcp"
+ }
+ rules = {
+      "action" = "permit"
+      "src_ip_address"= "0.0.0.0"
+      "src_ip_cidr"= 0
+      "dst_ip_address"= "any"
+      "dst_ip_cidr"= 32
+      "notes"= "Allow ping"
+      "protocol"= "icmp"
+ }
+}
+`
+
+const testAccCheckSoftLayerFwHardwareRules_update = testAccCheckSoftLayerFwHardwareRules_guest + `
+resource "softlayer_fw_hardware_rules" "rules" {
+ firewall_id = "${softlayer_fw_hardware.accfw4.id}"
+ rules = {
+      "action" = "permit"
+      "src_ip_address"= "0.0.0.0"
+      "src_ip_cidr"= 0
+      "dst_ip_address"= "any"
+      "dst_ip_cidr"= 32
+      "dst_port_range_start"= 443
+      "dst_port_range_end"= 443
+      "notes"= "Allow HTTPS"
+      "protocol"= "tcp"
+ }
+ rules = {
+      "action" = "permit"
+      "src_ip_address"= "0::"
+      "src_ip_cidr"= 0
+      "dst_ip_address"= "any"
+      "dst_ip_cidr"= 128
+      "dst_port_range_start"= 443
+      "dst_port_range_end"= 443
+      "notes"= "Allow HTTPS for IPv6"
+      "protocol"= "tcp"
+ }
+}
+`
@@ -0,0 +1,52 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerFwHardware_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFwHardware_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources("softlayer_fw_hardware.accfw", "virtual_guest_id",
+						"softlayer_virtual_guest.fwvm3", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_fw_hardware.accfw", "port_speed", "10"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_fw_hardware.accfw", "status"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "softlayer_fw_hardware.accfw",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerFwHardware_basic = `
+resource "softlayer_virtual_guest" "fwvm3" {
+    hostname = "fwvm3"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "sjc01"
+    network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_fw_hardware" "accfw" {
+  virtual_guest_id = "${softlayer_virtual_guest.fwvm3.id}"
+}`
@@ -0,0 +1,499 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	imageTemplateMask = "id,name,note,accountId,globalIdentifier,flexImageFlag,transactionId," +
+		"datacenters[name],accountReferences[accountId],children[transactionId]"
+	imageTemplateBlockDeviceMask = "id,device,mountType"
+)
+
+func resourceSoftLayerImageTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerImageTemplateCreate,
+		Read:     resourceSoftLayerImageTemplateRead,
+		Update:   resourceSoftLayerImageTemplateUpdate,
+		Delete:   resourceSoftLayerImageTemplateDelete,
+		Exists:   resourceSoftLayerImageTemplateExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"note": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"virtual_guest_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"uri"},
+			},
+
+			"flex_image": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"uri": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id"},
+			},
+
+			"os_reference_code": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"cloud_init": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"boot_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					bootMode := v.(string)
+					if bootMode != "HVM" && bootMode != "PV" {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either 'HVM' or 'PV'", k))
+					}
+					return
+				},
+			},
+
+			"datacenters": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"shared_account_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set: func(v interface{}) int {
+					return v.(int)
+				},
+			},
+
+			"global_identifier": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerImageTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	name := d.Get("name").(string)
+	note := d.Get("note").(string)
+
+	var id int
+	switch {
+	case d.Get("virtual_guest_id").(int) > 0:
+		guestId := d.Get("virtual_guest_id").(int)
+		guestService := services.GetVirtualGuestService(sess).Id(guestId)
+
+		if d.Get("flex_image").(bool) {
+			log.Printf("[INFO] Capturing flex image %s from virtual guest (%d)", name, guestId)
+			template, err := guestService.CaptureImage(&datatypes.Container_Disk_Image_Capture_Template{
+				Name:        sl.String(name),
+				Description: sl.String(note),
+			})
+			if err != nil {
+				return fmt.Errorf("Error capturing flex image from virtual guest (%d): %s", guestId, err)
+			}
+			id = *template.Id
+			break
+		}
+
+		// Archive all disks except the swap disk and CD-ROMs.
+		blockDevices, err := guestService.Mask(imageTemplateBlockDeviceMask).GetBlockDevices()
+		if err != nil {
+			return fmt.Errorf("Error retrieving block devices of virtual guest (%d): %s", guestId, err)
+		}
+		archiveDevices := make([]datatypes.Virtual_Guest_Block_Device, 0, len(blockDevices))
+		for _, blockDevice := range blockDevices {
+			if sl.Get(blockDevice.Device, "") == "1" || sl.Get(blockDevice.MountType, "") == "CD" {
+				continue
+			}
+			archiveDevices = append(archiveDevices, datatypes.Virtual_Guest_Block_Device{Id: blockDevice.Id})
+		}
+
+		log.Printf("[INFO] Capturing image %s from virtual guest (%d)", name, guestId)
+		transaction, err := guestService.CreateArchiveTransaction(sl.String(name), archiveDevices, sl.String(note))
+		if err != nil {
+			return fmt.Errorf("Error capturing image from virtual guest (%d): %s", guestId, err)
+		}
+
+		template, err := findImageTemplateByTransaction(sess, *transaction.Id)
+		if err != nil {
+			return fmt.Errorf("Error capturing image from virtual guest (%d): %s", guestId, err)
+		}
+		id = *template.Id
+
+	case d.Get("uri").(string) != "":
+		configuration := datatypes.Container_Virtual_Guest_Block_Device_Template_Configuration{
+			Name: sl.String(name),
+			Note: sl.String(note),
+			Uri:  sl.String(d.Get("uri").(string)),
+		}
+		if osReferenceCode, ok := d.GetOk("os_reference_code"); ok {
+			configuration.OperatingSystemReferenceCode = sl.String(osReferenceCode.(string))
+		}
+		if cloudInit, ok := d.GetOk("cloud_init"); ok {
+			configuration.CloudInit = sl.Bool(cloudInit.(bool))
+		}
+		if bootMode, ok := d.GetOk("boot_mode"); ok {
+			configuration.BootMode = sl.String(bootMode.(string))
+		}
+
+		log.Printf("[INFO] Importing image %s from %s", name, d.Get("uri").(string))
+		template, err := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).CreateFromExternalSource(&configuration)
+		if err != nil {
+			return fmt.Errorf("Error importing image from %s: %s", d.Get("uri").(string), err)
+		}
+		id = *template.Id
+
+	default:
+		return fmt.Errorf("One of virtual_guest_id or uri must be set")
+	}
+
+	d.SetId(strconv.Itoa(id))
+	log.Printf("[INFO] Image template ID: %s", d.Id())
+
+	_, err := waitForImageTemplateTransactions(sess, id)
+	if err != nil {
+		return fmt.Errorf("Error waiting for image template (%s) to become ready: %s", d.Id(), err)
+	}
+
+	if _, ok := d.GetOk("datacenters"); ok {
+		err = updateImageTemplateDatacenters(d, sess, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = updateImageTemplateSharedAccounts(d, sess, id)
+	if err != nil {
+		return err
+	}
+
+	return resourceSoftLayerImageTemplateRead(d, meta)
+}
+
+func resourceSoftLayerImageTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	template, err := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).
+		Id(id).
+		Mask(imageTemplateMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving image template: %s", err)
+	}
+
+	d.Set("name", sl.Get(template.Name, nil))
+	d.Set("note", sl.Get(template.Note, nil))
+	d.Set("flex_image", sl.Get(template.FlexImageFlag, false))
+	d.Set("global_identifier", sl.Get(template.GlobalIdentifier, nil))
+
+	datacenters := make([]string, 0, len(template.Datacenters))
+	for _, datacenter := range template.Datacenters {
+		datacenters = append(datacenters, *datacenter.Name)
+	}
+	d.Set("datacenters", datacenters)
+
+	// The owner account is referenced as well.
+	sharedAccountIds := make([]int, 0, len(template.AccountReferences))
+	for _, reference := range template.AccountReferences {
+		if reference.AccountId != nil && (template.AccountId == nil || *reference.AccountId != *template.AccountId) {
+			sharedAccountIds = append(sharedAccountIds, *reference.AccountId)
+		}
+	}
+	d.Set("shared_account_ids", sharedAccountIds)
+
+	return nil
+}
+
+func resourceSoftLayerImageTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("name") || d.HasChange("note") {
+		_, err = services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).
+			Id(id).
+			EditObject(&datatypes.Virtual_Guest_Block_Device_Template_Group{
+				Name: sl.String(d.Get("name").(string)),
+				Note: sl.String(d.Get("note").(string)),
+			})
+		if err != nil {
+			return fmt.Errorf("Error updating image template: %s", err)
+		}
+	}
+
+	if d.HasChange("datacenters") {
+		err = updateImageTemplateDatacenters(d, sess, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("shared_account_ids") {
+		err = updateImageTemplateSharedAccounts(d, sess, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerImageTemplateRead(d, meta)
+}
+
+func resourceSoftLayerImageTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	// Images can't be deleted while they are being captured or copied.
+	_, err = waitForImageTemplateTransactions(sess, id)
+	if err != nil {
+		return fmt.Errorf("Error waiting for image template (%d) to become ready: %s", id, err)
+	}
+
+	_, err = service.Id(id).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting image template: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"deleting"},
+		Target:  []string{"deleted"},
+		Refresh: func() (interface{}, string, error) {
+			_, err := service.Id(id).GetObject()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return true, "deleted", nil
+				}
+				return nil, "", err
+			}
+			return false, "deleting", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for image template (%d) to be deleted: %s", id, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerImageTemplateExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).Id(id).GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving image template: %s", err)
+	}
+	return true, nil
+}
+
+// Copies the image to the added datacenters and removes it from the removed datacenters.
+func updateImageTemplateDatacenters(d *schema.ResourceData, sess *session.Session, id int) error {
+	service := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).Id(id)
+
+	o, n := d.GetChange("datacenters")
+	added := n.(*schema.Set).Difference(o.(*schema.Set)).List()
+	removed := o.(*schema.Set).Difference(n.(*schema.Set)).List()
+
+	if len(added) > 0 {
+		locations, err := getImageTemplateLocations(sess, added)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Copying image template (%d) to %v", id, added)
+		_, err = service.AddLocations(locations)
+		if err != nil {
+			return fmt.Errorf("Error copying image template (%d) to datacenters: %s", id, err)
+		}
+	}
+
+	if len(removed) > 0 {
+		locations, err := getImageTemplateLocations(sess, removed)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Removing image template (%d) from %v", id, removed)
+		_, err = service.RemoveLocations(locations)
+		if err != nil {
+			return fmt.Errorf("Error removing image template (%d) from datacenters: %s", id, err)
+		}
+	}
+
+	_, err := waitForImageTemplateTransactions(sess, id)
+	if err != nil {
+		return fmt.Errorf("Error waiting for image template (%d) to be copied: %s", id, err)
+	}
+
+	return nil
+}
+
+func getImageTemplateLocations(sess *session.Session, datacenters []interface{}) ([]datatypes.Location, error) {
+	locations := make([]datatypes.Location, 0, len(datacenters))
+	for _, datacenter := range datacenters {
+		dc, err := location.GetDatacenterByName(sess, datacenter.(string), "id")
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, datatypes.Location{Id: dc.Id})
+	}
+	return locations, nil
+}
+
+// Shares the image with the added accounts and stops sharing it with the removed accounts.
+func updateImageTemplateSharedAccounts(d *schema.ResourceData, sess *session.Session, id int) error {
+	service := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).Id(id)
+
+	o, n := d.GetChange("shared_account_ids")
+	for _, accountId := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+		_, err := service.PermitSharingAccess(sl.Int(accountId.(int)))
+		if err != nil {
+			return fmt.Errorf("Error sharing image template (%d) with account %d: %s", id, accountId.(int), err)
+		}
+	}
+
+	for _, accountId := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+		_, err := service.DenySharingAccess(sl.Int(accountId.(int)))
+		if err != nil {
+			return fmt.Errorf("Error unsharing image template (%d) with account %d: %s", id, accountId.(int), err)
+		}
+	}
+
+	return nil
+}
+
+// Finds the image template which is created by the archive transaction.
+func findImageTemplateByTransaction(sess *session.Session, transactionId int) (datatypes.Virtual_Guest_Block_Device_Template_Group, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			templates, err := services.GetAccountService(sess).
+				Mask("id").
+				Filter(filter.Path("blockDeviceTemplateGroups.transactionId").Eq(transactionId).Build()).
+				GetBlockDeviceTemplateGroups()
+			if err != nil {
+				return datatypes.Virtual_Guest_Block_Device_Template_Group{}, "", err
+			}
+
+			// A nil result would count as not found, so an empty template is returned while pending.
+			if len(templates) == 0 {
+				return datatypes.Virtual_Guest_Block_Device_Template_Group{}, "pending", nil
+			}
+			return templates[0], "complete", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	template, err := stateConf.WaitForState()
+	if err != nil {
+		return datatypes.Virtual_Guest_Block_Device_Template_Group{}, err
+	}
+
+	return template.(datatypes.Virtual_Guest_Block_Device_Template_Group), nil
+}
+
+// Waits until the image template and its children have no active transactions.
+func waitForImageTemplateTransactions(sess *session.Session, id int) (interface{}, error) {
+	log.Printf("Waiting for active transactions of image template (%d) to finish.", id)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			template, err := services.GetVirtualGuestBlockDeviceTemplateGroupService(sess).
+				Id(id).
+				Mask("id,transactionId,children[transactionId]").
+				GetObject()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Error retrieving image template: %s", err)
+				}
+				return false, "retry", nil
+			}
+
+			if template.TransactionId != nil {
+				return template, "pending", nil
+			}
+			for _, child := range template.Children {
+				if child.TransactionId != nil {
+					return template, "pending", nil
+				}
+			}
+
+			return template, "complete", nil
+		},
+		Timeout:    2 * time.Hour,
+		Delay:      30 * time.Second,
+		MinTimeout: 30 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
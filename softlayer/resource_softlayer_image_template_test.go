@@ -0,0 +1,145 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerImageTemplate_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerImageTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerImageTemplateConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerImageTemplateExists("softlayer_image_template.golden"),
+					resource.TestCheckResourceAttr(
+						"softlayer_image_template.golden", "name", "terraform-test-golden"),
+					resource.TestCheckResourceAttr(
+						"softlayer_image_template.golden", "note", "Captured by Terraform"),
+					resource.TestCheckResourceAttr(
+						"softlayer_image_template.golden", "flex_image", "true"),
+					resource.TestMatchResourceAttr(
+						"softlayer_image_template.golden", "global_identifier", regexp.MustCompile("^[0-9a-f-]+$")),
+				),
+			},
+
+			{
+				Config: testAccCheckSoftLayerImageTemplateConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerImageTemplateExists("softlayer_image_template.golden"),
+					resource.TestCheckResourceAttr(
+						"softlayer_image_template.golden", "name", "terraform-test-golden-updated"),
+					resource.TestCheckResourceAttr(
+						"softlayer_image_template.golden", "note", "Updated by Terraform"),
+					resource.TestCheckResourceAttr(
+						"softlayer_image_template.golden", "datacenters.#", "2"),
+				),
+			},
+
+			{
+				ResourceName:            "softlayer_image_template.golden",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"virtual_guest_id"},
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerImageTemplateDestroy(s *terraform.State) error {
+	service := services.GetVirtualGuestBlockDeviceTemplateGroupService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_image_template" {
+			continue
+		}
+
+		id, _ := strconv.Atoi(rs.Primary.ID)
+
+		_, err := service.Id(id).GetObject()
+		if err == nil {
+			return fmt.Errorf("Image template %d still exists", id)
+		}
+		if apiErr, ok := err.(sl.Error); !ok || apiErr.StatusCode != 404 {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerImageTemplateExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		service := services.GetVirtualGuestBlockDeviceTemplateGroupService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+		template, err := service.Id(id).GetObject()
+		if err != nil {
+			return err
+		}
+
+		if *template.Id != id {
+			return fmt.Errorf("Image template not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerImageTemplateConfig_guest = `
+resource "softlayer_virtual_guest" "golden" {
+    hostname = "terraform-golden"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+`
+
+const testAccCheckSoftLayerImageTemplateConfig_basic = testAccCheckSoftLayerImageTemplateConfig_guest + `
+resource "softlayer_image_template" "golden" {
+    name = "terraform-test-golden"
+    note = "Captured by Terraform"
+    virtual_guest_id = "${softlayer_virtual_guest.golden.id}"
+    flex_image = true
+}
+`
+
+const testAccCheckSoftLayerImageTemplateConfig_update = testAccCheckSoftLayerImageTemplateConfig_guest + `
+resource "softlayer_image_template" "golden" {
+    name = "terraform-test-golden-updated"
+    note = "Updated by Terraform"
+    virtual_guest_id = "${softlayer_virtual_guest.golden.id}"
+    flex_image = true
+    datacenters = ["dal06", "wdc04"]
+}
+`
@@ -0,0 +1,788 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	ipsecVpnKeyName = "IPSEC_STANDARD"
+
+	ipsecVpnMask = "id,name,accountId,datacenter[name],customerPeerIpAddress,internalPeerIpAddress," +
+		"phaseOneAuthentication,phaseOneEncryption,phaseOneDiffieHellmanGroup,phaseOneKeylife," +
+		"phaseTwoAuthentication,phaseTwoEncryption,phaseTwoDiffieHellmanGroup,phaseTwoKeylife," +
+		"phaseTwoPerfectForwardSecrecy,presharedKey," +
+		"customerSubnets[id,networkIdentifier,cidr],internalSubnets[id],serviceSubnets[id]," +
+		"addressTranslations[id,customerIpAddress,internalIpAddress,notes]"
+)
+
+var (
+	ipsecVpnAuthentications     = []string{"MD5", "SHA1", "SHA256"}
+	ipsecVpnEncryptions         = []string{"DES", "3DES", "AES128", "AES192", "AES256"}
+	ipsecVpnDiffieHellmanGroups = []int{0, 1, 2, 5}
+)
+
+func resourceSoftLayerIpsecVpn() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerIpsecVpnCreate,
+		Read:     resourceSoftLayerIpsecVpnRead,
+		Update:   resourceSoftLayerIpsecVpnUpdate,
+		Delete:   resourceSoftLayerIpsecVpnDelete,
+		Exists:   resourceSoftLayerIpsecVpnExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"internal_peer_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"customer_peer_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					address := v.(string)
+					if net.ParseIP(address) == nil {
+						errors = append(errors, fmt.Errorf("Invalid IP format: %s", address))
+					}
+					return
+				},
+			},
+
+			"phase_one": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: ipsecVpnPhaseSchema(false),
+				},
+			},
+
+			"phase_two": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: ipsecVpnPhaseSchema(true),
+				},
+			},
+
+			"preshared_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"remote_subnet": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"remote_ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"remote_ip_cidr": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"internal_subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"service_subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"address_translation": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"remote_ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"internal_ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"notes": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Returns the schema of the proposal of a negotiation phase. Perfect forward secrecy is only
+// configured in phase two.
+func ipsecVpnPhaseSchema(phaseTwo bool) map[string]*schema.Schema {
+	phaseSchema := map[string]*schema.Schema{
+		"authentication": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateIpsecVpnOption(ipsecVpnAuthentications),
+		},
+		"encryption": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateIpsecVpnOption(ipsecVpnEncryptions),
+		},
+		"diffie_hellman_group": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+				group := v.(int)
+				for _, g := range ipsecVpnDiffieHellmanGroups {
+					if group == g {
+						return
+					}
+				}
+				errors = append(errors, fmt.Errorf("%s should be one of %v", k, ipsecVpnDiffieHellmanGroups))
+				return
+			},
+		},
+		"keylife": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+				keylife := v.(int)
+				if keylife < 120 || keylife > 172800 {
+					errors = append(errors, fmt.Errorf("%s should be between 120 and 172800 seconds", k))
+				}
+				return
+			},
+		},
+	}
+
+	if phaseTwo {
+		phaseSchema["perfect_forward_secrecy"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		}
+	}
+
+	return phaseSchema
+}
+
+func validateIpsecVpnOption(options []string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		for _, option := range options {
+			if value == option {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf("%s should be one of %v", k, options))
+		return
+	}
+}
+
+func resourceSoftLayerIpsecVpnCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	datacenter := d.Get("datacenter").(string)
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id")
+	if err != nil {
+		return fmt.Errorf("Error retrieving datacenter %s: %s", datacenter, err)
+	}
+
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return err
+	}
+
+	targetItems := []datatypes.Product_Item{}
+	for _, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == ipsecVpnKeyName {
+			targetItems = append(targetItems, item)
+		}
+	}
+
+	if len(targetItems) == 0 {
+		return fmt.Errorf("No product items matching %s could be found", ipsecVpnKeyName)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Tunnel_Ipsec{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: targetItems[0].Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+	}
+
+	log.Println("[INFO] Creating IPSec VPN")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of IPSec VPN: %s", err)
+	}
+
+	vpn, err := findIpsecVpnByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of IPSec VPN: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(*vpn.Id))
+
+	log.Printf("[INFO] IPSec VPN ID: %s", d.Id())
+
+	err = applyIpsecVpnConfiguration(sess, *vpn.Id, d)
+	if err != nil {
+		return fmt.Errorf("Error configuring IPSec VPN %d: %s", *vpn.Id, err)
+	}
+
+	return resourceSoftLayerIpsecVpnRead(d, meta)
+}
+
+func resourceSoftLayerIpsecVpnRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	vpn, err := services.GetNetworkTunnelModuleContextService(sess).
+		Id(vpnId).
+		Mask(ipsecVpnMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving IPSec VPN: %s", err)
+	}
+
+	if vpn.Datacenter != nil {
+		d.Set("datacenter", sl.Get(vpn.Datacenter.Name, nil))
+	}
+	d.Set("name", sl.Get(vpn.Name, nil))
+	d.Set("internal_peer_ip_address", sl.Get(vpn.InternalPeerIpAddress, nil))
+	d.Set("customer_peer_ip", sl.Get(vpn.CustomerPeerIpAddress, nil))
+	d.Set("preshared_key", sl.Get(vpn.PresharedKey, nil))
+
+	d.Set("phase_one", []map[string]interface{}{
+		{
+			"authentication":       sl.Get(vpn.PhaseOneAuthentication, ""),
+			"encryption":           sl.Get(vpn.PhaseOneEncryption, ""),
+			"diffie_hellman_group": sl.Get(vpn.PhaseOneDiffieHellmanGroup, 0),
+			"keylife":              sl.Get(vpn.PhaseOneKeylife, 0),
+		},
+	})
+	d.Set("phase_two", []map[string]interface{}{
+		{
+			"authentication":          sl.Get(vpn.PhaseTwoAuthentication, ""),
+			"encryption":              sl.Get(vpn.PhaseTwoEncryption, ""),
+			"diffie_hellman_group":    sl.Get(vpn.PhaseTwoDiffieHellmanGroup, 0),
+			"keylife":                 sl.Get(vpn.PhaseTwoKeylife, 0),
+			"perfect_forward_secrecy": sl.Get(vpn.PhaseTwoPerfectForwardSecrecy, 0).(int) == 1,
+		},
+	})
+
+	remoteSubnets := make([]map[string]interface{}, 0, len(vpn.CustomerSubnets))
+	for _, subnet := range vpn.CustomerSubnets {
+		remoteSubnets = append(remoteSubnets, map[string]interface{}{
+			"remote_ip_address": sl.Get(subnet.NetworkIdentifier, ""),
+			"remote_ip_cidr":    sl.Get(subnet.Cidr, 0),
+		})
+	}
+	d.Set("remote_subnet", remoteSubnets)
+
+	d.Set("internal_subnet_ids", ipsecVpnSubnetIds(vpn.InternalSubnets))
+	d.Set("service_subnet_ids", ipsecVpnSubnetIds(vpn.ServiceSubnets))
+
+	translations := make([]map[string]interface{}, 0, len(vpn.AddressTranslations))
+	for _, translation := range vpn.AddressTranslations {
+		translations = append(translations, map[string]interface{}{
+			"remote_ip_address":   sl.Get(translation.CustomerIpAddress, ""),
+			"internal_ip_address": sl.Get(translation.InternalIpAddress, ""),
+			"notes":               sl.Get(translation.Notes, ""),
+		})
+	}
+	d.Set("address_translation", translations)
+
+	return nil
+}
+
+func resourceSoftLayerIpsecVpnUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	err = applyIpsecVpnConfiguration(sess, vpnId, d)
+	if err != nil {
+		return fmt.Errorf("Error configuring IPSec VPN %d: %s", vpnId, err)
+	}
+
+	return resourceSoftLayerIpsecVpnRead(d, meta)
+}
+
+func resourceSoftLayerIpsecVpnDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := services.GetNetworkTunnelModuleContextService(sess).Id(vpnId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error while looking up billing item associated with the IPSec VPN: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error while looking up billing item associated with the IPSec VPN: No billing item for ID:%d", vpnId)
+	}
+
+	success, err := services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful cancellation")
+	}
+
+	return nil
+}
+
+func resourceSoftLayerIpsecVpnExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	vpn, err := services.GetNetworkTunnelModuleContextService(sess).
+		Id(vpnId).
+		Mask("id").
+		GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving IPSec VPN: %s", err)
+	}
+
+	return vpn.Id != nil && *vpn.Id == vpnId, nil
+}
+
+// Configures the IPSec VPN as described by the resource data, and applies the configuration to the device.
+// The configuration is compared with the one of SoftLayer, so that changes made outside of terraform
+// are reverted too.
+func applyIpsecVpnConfiguration(sess *session.Session, vpnId int, d *schema.ResourceData) error {
+	service := services.GetNetworkTunnelModuleContextService(sess).Id(vpnId)
+
+	vpn, err := service.Mask(ipsecVpnMask).GetObject()
+	if err != nil {
+		return err
+	}
+
+	_, err = service.EditObject(expandIpsecVpn(d))
+	if err != nil {
+		return fmt.Errorf("Error updating the IPSec VPN parameters: %s", err)
+	}
+
+	removeTranslations, createTranslations, editTranslations := diffIpsecVpnAddressTranslations(
+		vpn.AddressTranslations, d.Get("address_translation").(*schema.Set).List())
+
+	// Address translations must be removed before the subnets of their addresses.
+	for _, translationId := range removeTranslations {
+		_, err = service.DeleteAddressTranslation(sl.Int(translationId))
+		if err != nil {
+			return fmt.Errorf("Error removing address translation %d: %s", translationId, err)
+		}
+	}
+
+	err = applyIpsecVpnRemoteSubnets(sess, vpn, d.Get("remote_subnet").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	removeIds, addIds := diffIpsecVpnSubnetIds(ipsecVpnSubnetIds(vpn.InternalSubnets), d.Get("internal_subnet_ids").(*schema.Set).List())
+	for _, subnetId := range removeIds {
+		if _, err = service.RemovePrivateSubnetFromNetworkTunnel(sl.Int(subnetId)); err != nil {
+			return fmt.Errorf("Error removing private subnet %d: %s", subnetId, err)
+		}
+	}
+	for _, subnetId := range addIds {
+		if _, err = service.AddPrivateSubnetToNetworkTunnel(sl.Int(subnetId)); err != nil {
+			return fmt.Errorf("Error adding private subnet %d: %s", subnetId, err)
+		}
+	}
+
+	removeIds, addIds = diffIpsecVpnSubnetIds(ipsecVpnSubnetIds(vpn.ServiceSubnets), d.Get("service_subnet_ids").(*schema.Set).List())
+	for _, subnetId := range removeIds {
+		if _, err = service.RemoveServiceSubnetFromNetworkTunnel(sl.Int(subnetId)); err != nil {
+			return fmt.Errorf("Error removing service subnet %d: %s", subnetId, err)
+		}
+	}
+	for _, subnetId := range addIds {
+		if _, err = service.AddServiceSubnetToNetworkTunnel(sl.Int(subnetId)); err != nil {
+			return fmt.Errorf("Error adding service subnet %d: %s", subnetId, err)
+		}
+	}
+
+	for _, translation := range createTranslations {
+		if _, err = service.CreateAddressTranslation(&translation); err != nil {
+			return fmt.Errorf("Error creating address translation for %s: %s", *translation.InternalIpAddress, err)
+		}
+	}
+	for _, translation := range editTranslations {
+		if _, err = service.EditAddressTranslation(&translation); err != nil {
+			return fmt.Errorf("Error updating address translation %d: %s", *translation.Id, err)
+		}
+	}
+
+	log.Printf("[INFO] Applying the configuration of IPSec VPN %d", vpnId)
+
+	_, err = service.ApplyConfigurationsToDevice()
+	if err != nil {
+		return fmt.Errorf("Error applying the configuration to the device: %s", err)
+	}
+
+	return waitForIpsecVpnTransaction(sess, vpnId)
+}
+
+// Returns the parameters of the IPSec VPN which are edited on the tunnel context itself.
+func expandIpsecVpn(d *schema.ResourceData) *datatypes.Network_Tunnel_Module_Context {
+	vpn := &datatypes.Network_Tunnel_Module_Context{}
+
+	if peer, ok := d.GetOk("customer_peer_ip"); ok {
+		vpn.CustomerPeerIpAddress = sl.String(peer.(string))
+	}
+	if key, ok := d.GetOk("preshared_key"); ok {
+		vpn.PresharedKey = sl.String(key.(string))
+	}
+
+	if v, ok := d.GetOk("phase_one.0.authentication"); ok {
+		vpn.PhaseOneAuthentication = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_one.0.encryption"); ok {
+		vpn.PhaseOneEncryption = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_one.0.diffie_hellman_group"); ok {
+		vpn.PhaseOneDiffieHellmanGroup = sl.Int(v.(int))
+	}
+	if v, ok := d.GetOk("phase_one.0.keylife"); ok {
+		vpn.PhaseOneKeylife = sl.Int(v.(int))
+	}
+
+	if v, ok := d.GetOk("phase_two.0.authentication"); ok {
+		vpn.PhaseTwoAuthentication = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_two.0.encryption"); ok {
+		vpn.PhaseTwoEncryption = sl.String(v.(string))
+	}
+	// Group 0 disables Diffie-Hellman in phase two, so it is sent whenever phase two is configured.
+	if _, ok := d.GetOk("phase_two"); ok {
+		vpn.PhaseTwoDiffieHellmanGroup = sl.Int(d.Get("phase_two.0.diffie_hellman_group").(int))
+		vpn.PhaseTwoPerfectForwardSecrecy = sl.Int(0)
+		if d.Get("phase_two.0.perfect_forward_secrecy").(bool) {
+			vpn.PhaseTwoPerfectForwardSecrecy = sl.Int(1)
+		}
+	}
+	if v, ok := d.GetOk("phase_two.0.keylife"); ok {
+		vpn.PhaseTwoKeylife = sl.Int(v.(int))
+	}
+
+	return vpn
+}
+
+// Adds the configured remote subnets which aren't routed through the tunnel yet, and removes the others.
+// Remote subnets are customer subnets of the account, which are created when they don't exist.
+func applyIpsecVpnRemoteSubnets(sess *session.Session, vpn datatypes.Network_Tunnel_Module_Context, remoteSubnets []interface{}) error {
+	service := services.GetNetworkTunnelModuleContextService(sess).Id(*vpn.Id)
+
+	desired := make(map[string]bool, len(remoteSubnets))
+	for _, s := range remoteSubnets {
+		subnet := s.(map[string]interface{})
+		desired[fmt.Sprintf("%s/%d", subnet["remote_ip_address"].(string), subnet["remote_ip_cidr"].(int))] = true
+	}
+
+	current := make(map[string]bool, len(vpn.CustomerSubnets))
+	for _, subnet := range vpn.CustomerSubnets {
+		key := fmt.Sprintf("%s/%d", sl.Get(subnet.NetworkIdentifier, ""), sl.Get(subnet.Cidr, 0))
+		current[key] = true
+		if !desired[key] {
+			if _, err := service.RemoveCustomerSubnetFromNetworkTunnel(subnet.Id); err != nil {
+				return fmt.Errorf("Error removing remote subnet %s: %s", key, err)
+			}
+		}
+	}
+
+	var accountSubnetIds map[string]int
+	for _, s := range remoteSubnets {
+		subnet := s.(map[string]interface{})
+		address := subnet["remote_ip_address"].(string)
+		cidr := subnet["remote_ip_cidr"].(int)
+		key := fmt.Sprintf("%s/%d", address, cidr)
+		if current[key] {
+			continue
+		}
+
+		if accountSubnetIds == nil {
+			var err error
+			accountSubnetIds, err = getIpsecVpnAccountCustomerSubnetIds(sess)
+			if err != nil {
+				return fmt.Errorf("Error retrieving remote subnets of the account: %s", err)
+			}
+		}
+
+		// Customer subnets can't be deleted, so a subnet which the account already has is reused.
+		subnetId, ok := accountSubnetIds[key]
+		if !ok {
+			customerSubnet, err := services.GetNetworkCustomerSubnetService(sess).CreateObject(&datatypes.Network_Customer_Subnet{
+				AccountId:         vpn.AccountId,
+				NetworkIdentifier: sl.String(address),
+				Cidr:              sl.Int(cidr),
+			})
+			if err != nil {
+				return fmt.Errorf("Error creating remote subnet %s: %s", key, err)
+			}
+			subnetId = *customerSubnet.Id
+			accountSubnetIds[key] = subnetId
+		}
+
+		if _, err := service.AddCustomerSubnetToNetworkTunnel(sl.Int(subnetId)); err != nil {
+			return fmt.Errorf("Error adding remote subnet %s: %s", key, err)
+		}
+	}
+
+	return nil
+}
+
+// Returns the IDs of the customer subnets of the account by network identifier and CIDR.
+// Customer subnets are only exposed through the IPSec VPNs which they are added to.
+func getIpsecVpnAccountCustomerSubnetIds(sess *session.Session) (map[string]int, error) {
+	vpns, err := services.GetAccountService(sess).
+		Mask("customerSubnets[id,networkIdentifier,cidr]").
+		GetNetworkTunnelContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	subnetIds := make(map[string]int)
+	for _, vpn := range vpns {
+		for _, subnet := range vpn.CustomerSubnets {
+			if subnet.Id == nil {
+				continue
+			}
+			subnetIds[fmt.Sprintf("%s/%d", sl.Get(subnet.NetworkIdentifier, ""), sl.Get(subnet.Cidr, 0))] = *subnet.Id
+		}
+	}
+	return subnetIds, nil
+}
+
+func ipsecVpnSubnetIds(subnets []datatypes.Network_Subnet) []int {
+	ids := make([]int, 0, len(subnets))
+	for _, subnet := range subnets {
+		if subnet.Id != nil {
+			ids = append(ids, *subnet.Id)
+		}
+	}
+	return ids
+}
+
+// Returns the IDs of the current subnets which aren't configured, and of the configured subnets
+// which aren't current.
+func diffIpsecVpnSubnetIds(current []int, configured []interface{}) ([]int, []int) {
+	desired := make(map[int]bool, len(configured))
+	for _, id := range configured {
+		desired[id.(int)] = true
+	}
+
+	existing := make(map[int]bool, len(current))
+	remove := []int{}
+	for _, id := range current {
+		existing[id] = true
+		if !desired[id] {
+			remove = append(remove, id)
+		}
+	}
+
+	add := []int{}
+	for _, id := range configured {
+		if !existing[id.(int)] {
+			add = append(add, id.(int))
+		}
+	}
+
+	return remove, add
+}
+
+// Compares the current address translations with the configured ones. Translations are identified by
+// their remote and internal IP addresses. Returns the IDs of the translations to remove, the translations
+// to create, and the translations whose notes have to be updated.
+func diffIpsecVpnAddressTranslations(current []datatypes.Network_Tunnel_Module_Context_Address_Translation,
+	configured []interface{}) ([]int, []datatypes.Network_Tunnel_Module_Context_Address_Translation,
+	[]datatypes.Network_Tunnel_Module_Context_Address_Translation) {
+
+	desired := make(map[string]map[string]interface{}, len(configured))
+	for _, t := range configured {
+		translation := t.(map[string]interface{})
+		desired[translation["remote_ip_address"].(string)+"-"+translation["internal_ip_address"].(string)] = translation
+	}
+
+	existing := make(map[string]bool, len(current))
+	remove := []int{}
+	edit := []datatypes.Network_Tunnel_Module_Context_Address_Translation{}
+	for _, translation := range current {
+		key := sl.Get(translation.CustomerIpAddress, "").(string) + "-" + sl.Get(translation.InternalIpAddress, "").(string)
+		existing[key] = true
+
+		config, ok := desired[key]
+		if !ok {
+			remove = append(remove, *translation.Id)
+			continue
+		}
+		if notes := config["notes"].(string); notes != sl.Get(translation.Notes, "").(string) {
+			translation.Notes = sl.String(notes)
+			edit = append(edit, translation)
+		}
+	}
+
+	create := []datatypes.Network_Tunnel_Module_Context_Address_Translation{}
+	for _, t := range configured {
+		translation := t.(map[string]interface{})
+		if existing[translation["remote_ip_address"].(string)+"-"+translation["internal_ip_address"].(string)] {
+			continue
+		}
+		create = append(create, datatypes.Network_Tunnel_Module_Context_Address_Translation{
+			CustomerIpAddress: sl.String(translation["remote_ip_address"].(string)),
+			InternalIpAddress: sl.String(translation["internal_ip_address"].(string)),
+			Notes:             sl.String(translation["notes"].(string)),
+		})
+	}
+
+	return remove, create, edit
+}
+
+func findIpsecVpnByOrderId(sess *session.Session, orderId int) (datatypes.Network_Tunnel_Module_Context, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			vpns, err := services.GetAccountService(sess).
+				Filter(filter.Path("networkTunnelContexts.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id").
+				GetNetworkTunnelContexts()
+			if err != nil {
+				return datatypes.Network_Tunnel_Module_Context{}, "", err
+			}
+
+			if len(vpns) == 1 {
+				return vpns[0], "complete", nil
+			} else if len(vpns) == 0 {
+				return datatypes.Network_Tunnel_Module_Context{}, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one IPSec VPN: %s", err)
+			}
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+	if err != nil {
+		return datatypes.Network_Tunnel_Module_Context{}, err
+	}
+
+	if result, ok := pendingResult.(datatypes.Network_Tunnel_Module_Context); ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Tunnel_Module_Context{},
+		fmt.Errorf("Cannot find IPSec VPN with order id '%d'", orderId)
+}
+
+// Waits until the configuration of the IPSec VPN has been applied to the device.
+func waitForIpsecVpnTransaction(sess *session.Session, vpnId int) error {
+	service := services.GetNetworkTunnelModuleContextService(sess)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			transaction, err := service.Id(vpnId).GetActiveTransaction()
+			if err != nil {
+				return nil, "", err
+			}
+			if transaction.Id == nil {
+				return transaction, "complete", nil
+			}
+			return transaction, "pending", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for the configuration to be applied: %s", err)
+	}
+
+	return nil
+}
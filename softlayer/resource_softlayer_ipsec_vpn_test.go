@@ -0,0 +1,185 @@
+package softlayer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerIpsecVpn_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerIpsecVpnConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "datacenter", "tok02"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_ipsec_vpn.vpn", "internal_peer_ip_address"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "customer_peer_ip", "198.51.100.10"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_one.0.authentication", "SHA256"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_one.0.encryption", "AES256"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_one.0.diffie_hellman_group", "5"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_one.0.keylife", "14400"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_two.0.perfect_forward_secrecy", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "preshared_key", "tfuatsecret"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "remote_subnet.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerIpsecVpnConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_one.0.encryption", "AES128"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "phase_two.0.perfect_forward_secrecy", "false"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "preshared_key", "tfuatsecret2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.vpn", "remote_subnet.#", "2"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "softlayer_ipsec_vpn.vpn",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDiffIpsecVpnSubnetIds(t *testing.T) {
+	remove, add := diffIpsecVpnSubnetIds([]int{1, 2, 3}, []interface{}{2, 3, 4})
+	if !reflect.DeepEqual(remove, []int{1}) {
+		t.Errorf("Expected to remove [1], got %v", remove)
+	}
+	if !reflect.DeepEqual(add, []int{4}) {
+		t.Errorf("Expected to add [4], got %v", add)
+	}
+
+	remove, add = diffIpsecVpnSubnetIds(nil, []interface{}{})
+	if len(remove) != 0 || len(add) != 0 {
+		t.Errorf("Expected no changes, got %v and %v", remove, add)
+	}
+}
+
+func TestDiffIpsecVpnAddressTranslations(t *testing.T) {
+	current := []datatypes.Network_Tunnel_Module_Context_Address_Translation{
+		{
+			Id:                sl.Int(1),
+			CustomerIpAddress: sl.String("192.168.1.10"),
+			InternalIpAddress: sl.String("10.0.0.10"),
+			Notes:             sl.String("web"),
+		},
+		{
+			Id:                sl.Int(2),
+			CustomerIpAddress: sl.String("192.168.1.11"),
+			InternalIpAddress: sl.String("10.0.0.11"),
+		},
+		{
+			Id:                sl.Int(3),
+			CustomerIpAddress: sl.String("192.168.1.12"),
+			InternalIpAddress: sl.String("10.0.0.12"),
+			Notes:             sl.String("db"),
+		},
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{
+			"remote_ip_address":   "192.168.1.10",
+			"internal_ip_address": "10.0.0.10",
+			"notes":               "web",
+		},
+		map[string]interface{}{
+			"remote_ip_address":   "192.168.1.12",
+			"internal_ip_address": "10.0.0.12",
+			"notes":               "database",
+		},
+		map[string]interface{}{
+			"remote_ip_address":   "192.168.1.13",
+			"internal_ip_address": "10.0.0.13",
+			"notes":               "",
+		},
+	}
+
+	remove, create, edit := diffIpsecVpnAddressTranslations(current, configured)
+
+	if !reflect.DeepEqual(remove, []int{2}) {
+		t.Errorf("Expected to remove [2], got %v", remove)
+	}
+	if len(create) != 1 || *create[0].InternalIpAddress != "10.0.0.13" || *create[0].CustomerIpAddress != "192.168.1.13" {
+		t.Errorf("Expected to create the translation of 10.0.0.13, got %+v", create)
+	}
+	if len(edit) != 1 || *edit[0].Id != 3 || *edit[0].Notes != "database" {
+		t.Errorf("Expected to update the notes of translation 3, got %+v", edit)
+	}
+}
+
+const testAccCheckSoftLayerIpsecVpnConfig_basic = `
+resource "softlayer_ipsec_vpn" "vpn" {
+    datacenter = "tok02"
+    customer_peer_ip = "198.51.100.10"
+    phase_one {
+        authentication = "SHA256"
+        encryption = "AES256"
+        diffie_hellman_group = 5
+        keylife = 14400
+    }
+    phase_two {
+        authentication = "SHA256"
+        encryption = "AES256"
+        diffie_hellman_group = 5
+        keylife = 3600
+        perfect_forward_secrecy = true
+    }
+    preshared_key = "tfuatsecret"
+    remote_subnet {
+        remote_ip_address = "192.168.100.0"
+        remote_ip_cidr = 24
+    }
+}
+`
+
+const testAccCheckSoftLayerIpsecVpnConfig_update = `
+resource "softlayer_ipsec_vpn" "vpn" {
+    datacenter = "tok02"
+    customer_peer_ip = "198.51.100.10"
+    phase_one {
+        authentication = "SHA256"
+        encryption = "AES128"
+        diffie_hellman_group = 5
+        keylife = 14400
+    }
+    phase_two {
+        authentication = "SHA256"
+        encryption = "AES128"
+        diffie_hellman_group = 2
+        keylife = 3600
+        perfect_forward_secrecy = false
+    }
+    preshared_key = "tfuatsecret2"
+    remote_subnet {
+        remote_ip_address = "192.168.100.0"
+        remote_ip_cidr = 24
+    }
+    remote_subnet {
+        remote_ip_address = "192.168.200.0"
+        remote_ip_cidr = 24
+    }
+}
+`
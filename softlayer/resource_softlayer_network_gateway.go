@@ -0,0 +1,577 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	networkGatewayPackageType = "BARE_METAL_GATEWAY"
+
+	networkGatewayMask = "id,name,status[keyName],publicVlanId,privateVlanId," +
+		"publicIpAddress[ipAddress],privateIpAddress[ipAddress],publicIpv6Address[ipAddress]," +
+		"members[hardwareId,priority,hardware[hostname,domain]]," +
+		"insideVlans[id,networkVlanId,bypassFlag]"
+)
+
+func resourceSoftLayerNetworkGateway() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceSoftLayerNetworkGatewayCreate,
+		Read:          resourceSoftLayerNetworkGatewayRead,
+		Update:        resourceSoftLayerNetworkGatewayUpdate,
+		Delete:        resourceSoftLayerNetworkGatewayDelete,
+		Exists:        resourceSoftLayerNetworkGatewayExists,
+		CustomizeDiff: validateNetworkGatewayOrder,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"members": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				MaxItems: 2,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"domain": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"datacenter": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"package_key_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"process_key_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"os_key_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"memory": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"network_speed": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"redundant_network": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"unbonded_network": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"private_network_only": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"tcp_monitoring": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"public_bandwidth": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"disk_key_names": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"redundant_power_supply": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ForceNew:         true,
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"ssh_key_ids": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				Elem:             &schema.Schema{Type: schema.TypeInt},
+				DiffSuppressFunc: applyOnce,
+			},
+
+			"public_vlan_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"private_vlan_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"member_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"public_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"private_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_ipv6_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"associated_vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"association_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"network_vlan_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"bypass": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// networkGatewayMemberData reads the order options of one gateway member. The hostname and
+// domain are the member's own, and all other options are shared by the members of the gateway.
+type networkGatewayMemberData struct {
+	resourceDataGetter
+	values map[string]interface{}
+}
+
+func (m networkGatewayMemberData) Get(key string) interface{} {
+	if v, ok := m.values[key]; ok {
+		return v
+	}
+	return m.resourceDataGetter.Get(key)
+}
+
+func (m networkGatewayMemberData) GetOk(key string) (interface{}, bool) {
+	if v, ok := m.values[key]; ok {
+		return v, v != nil
+	}
+	return m.resourceDataGetter.GetOk(key)
+}
+
+// Builds the order of the gateway members. Gateways are monthly bare metal servers, so the order is built
+// like a monthly bare metal server order, with one hardware entry per member.
+func getNetworkGatewayOrder(d resourceDataGetter, meta interface{}) (datatypes.Container_Product_Order_Hardware_Server_Gateway_Appliance, error) {
+	members := d.Get("members").([]interface{})
+	if len(members) == 0 {
+		return datatypes.Container_Product_Order_Hardware_Server_Gateway_Appliance{},
+			fmt.Errorf("The attribute 'members' is not defined.")
+	}
+
+	hardware := make([]datatypes.Hardware, 0, len(members))
+	for _, m := range members {
+		member := m.(map[string]interface{})
+		hardware = append(hardware, datatypes.Hardware{
+			Hostname: sl.String(member["hostname"].(string)),
+			Domain:   sl.String(member["domain"].(string)),
+		})
+	}
+
+	memberData := networkGatewayMemberData{
+		resourceDataGetter: d,
+		values: map[string]interface{}{
+			"hostname":       *hardware[0].Hostname,
+			"domain":         *hardware[0].Domain,
+			"hourly_billing": false,
+			"storage_groups": nil,
+		},
+	}
+
+	order, err := getMonthlyHardwareOrder(memberData, meta, networkGatewayPackageType)
+	if err != nil {
+		return datatypes.Container_Product_Order_Hardware_Server_Gateway_Appliance{}, err
+	}
+
+	order.Quantity = sl.Int(len(hardware))
+	order.Hardware = hardware
+
+	gatewayOrder := datatypes.Container_Product_Order_Hardware_Server_Gateway_Appliance{
+		Container_Product_Order_Hardware_Server: datatypes.Container_Product_Order_Hardware_Server{
+			Container_Product_Order: order,
+		},
+	}
+
+	// Two members are ordered as a high availability pair.
+	if len(hardware) == 2 {
+		gatewayOrder.ClusterOrderType = sl.String("HA")
+	}
+
+	return gatewayOrder, nil
+}
+
+func resourceSoftLayerNetworkGatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	order, err := getNetworkGatewayOrder(d, meta)
+	if err != nil {
+		return fmt.Errorf("Encountered problem trying to get the network gateway order template: %s", err)
+	}
+
+	publicVlanId := d.Get("public_vlan_id").(int)
+	privateVlanId := d.Get("private_vlan_id").(int)
+	sshKeyIds := d.Get("ssh_key_ids").([]interface{})
+	for i := range order.Hardware {
+		if publicVlanId > 0 {
+			order.Hardware[i].PrimaryNetworkComponent = &datatypes.Network_Component{
+				NetworkVlan: &datatypes.Network_Vlan{Id: sl.Int(publicVlanId)},
+			}
+		}
+		if privateVlanId > 0 {
+			order.Hardware[i].PrimaryBackendNetworkComponent = &datatypes.Network_Component{
+				NetworkVlan: &datatypes.Network_Vlan{Id: sl.Int(privateVlanId)},
+			}
+		}
+		for _, sshKeyId := range sshKeyIds {
+			order.Hardware[i].SshKeys = append(order.Hardware[i].SshKeys, datatypes.Security_Ssh_Key{
+				Id: sl.Int(sshKeyId.(int)),
+			})
+		}
+	}
+
+	log.Println("[INFO] Ordering network gateway")
+	_, err = services.GetProductOrderService(sess).PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error ordering network gateway: %s", err)
+	}
+
+	memberIds := make([]int, 0, len(order.Hardware))
+	for i := range order.Hardware {
+		bm, err := waitForBareMetalProvision(&order.Hardware[i], meta)
+		if err != nil {
+			return fmt.Errorf("Error waiting for network gateway member %s to become ready: %s",
+				*order.Hardware[i].Hostname, err)
+		}
+		memberIds = append(memberIds, *bm.(datatypes.Hardware).Id)
+	}
+
+	gatewayId, err := waitForNetworkGateway(sess, memberIds[0])
+	if err != nil {
+		return fmt.Errorf("Error waiting for network gateway of member %d: %s", memberIds[0], err)
+	}
+
+	d.SetId(strconv.Itoa(gatewayId))
+
+	log.Printf("[INFO] Network Gateway ID: %s", d.Id())
+
+	_, err = services.GetNetworkGatewayService(sess).Id(gatewayId).EditObject(&datatypes.Network_Gateway{
+		Name: sl.String(d.Get("name").(string)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error setting the name of network gateway %d: %s", gatewayId, err)
+	}
+
+	return resourceSoftLayerNetworkGatewayRead(d, meta)
+}
+
+func resourceSoftLayerNetworkGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	gatewayId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	gateway, err := services.GetNetworkGatewayService(sess).
+		Id(gatewayId).
+		Mask(networkGatewayMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving network gateway: %s", err)
+	}
+
+	d.Set("name", sl.Get(gateway.Name, nil))
+	d.Set("public_vlan_id", sl.Get(gateway.PublicVlanId, nil))
+	d.Set("private_vlan_id", sl.Get(gateway.PrivateVlanId, nil))
+
+	if gateway.Status != nil {
+		d.Set("status", sl.Get(gateway.Status.KeyName, nil))
+	}
+	if gateway.PublicIpAddress != nil {
+		d.Set("public_ip_address", sl.Get(gateway.PublicIpAddress.IpAddress, nil))
+	}
+	if gateway.PrivateIpAddress != nil {
+		d.Set("private_ip_address", sl.Get(gateway.PrivateIpAddress.IpAddress, nil))
+	}
+	if gateway.PublicIpv6Address != nil {
+		d.Set("public_ipv6_address", sl.Get(gateway.PublicIpv6Address.IpAddress, nil))
+	}
+
+	members, memberIds := flattenNetworkGatewayMembers(d.Get("members").([]interface{}), gateway.Members)
+	d.Set("members", members)
+	d.Set("member_ids", memberIds)
+
+	vlans := make([]map[string]interface{}, 0, len(gateway.InsideVlans))
+	for _, vlan := range gateway.InsideVlans {
+		vlans = append(vlans, map[string]interface{}{
+			"association_id":  sl.Get(vlan.Id, 0),
+			"network_vlan_id": sl.Get(vlan.NetworkVlanId, 0),
+			"bypass":          sl.Get(vlan.BypassFlag, false),
+		})
+	}
+	d.Set("associated_vlans", vlans)
+
+	return nil
+}
+
+// Returns the members of the gateway and their hardware IDs. Members which are already in the
+// configuration keep their position, so that the order of the members doesn't cause a diff.
+func flattenNetworkGatewayMembers(configured []interface{}, gatewayMembers []datatypes.Network_Gateway_Member) ([]map[string]interface{}, []int) {
+	remaining := make([]datatypes.Network_Gateway_Member, 0, len(gatewayMembers))
+	for _, member := range gatewayMembers {
+		if member.Hardware != nil && member.HardwareId != nil {
+			remaining = append(remaining, member)
+		}
+	}
+
+	ordered := make([]datatypes.Network_Gateway_Member, 0, len(remaining))
+	for _, c := range configured {
+		hostname := c.(map[string]interface{})["hostname"].(string)
+		for i, member := range remaining {
+			if sl.Get(member.Hardware.Hostname, "").(string) == hostname {
+				ordered = append(ordered, member)
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	ordered = append(ordered, remaining...)
+
+	members := make([]map[string]interface{}, 0, len(ordered))
+	memberIds := make([]int, 0, len(ordered))
+	for _, member := range ordered {
+		members = append(members, map[string]interface{}{
+			"hostname": sl.Get(member.Hardware.Hostname, ""),
+			"domain":   sl.Get(member.Hardware.Domain, ""),
+		})
+		memberIds = append(memberIds, *member.HardwareId)
+	}
+
+	return members, memberIds
+}
+
+func resourceSoftLayerNetworkGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	gatewayId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("name") {
+		_, err = services.GetNetworkGatewayService(sess).Id(gatewayId).EditObject(&datatypes.Network_Gateway{
+			Name: sl.String(d.Get("name").(string)),
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating the name of network gateway %d: %s", gatewayId, err)
+		}
+	}
+
+	return resourceSoftLayerNetworkGatewayRead(d, meta)
+}
+
+func resourceSoftLayerNetworkGatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetHardwareService(sess)
+
+	gatewayId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	gateway, err := services.GetNetworkGatewayService(sess).
+		Id(gatewayId).
+		Mask("members[hardwareId]").
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving network gateway: %s", err)
+	}
+
+	// The gateway is removed when the servers of its members are canceled.
+	for _, member := range gateway.Members {
+		if member.HardwareId == nil {
+			continue
+		}
+		id := *member.HardwareId
+
+		_, err = waitForNoBareMetalActiveTransactions(id, meta)
+		if err != nil {
+			return fmt.Errorf("Error deleting network gateway member %d while waiting for zero active transactions: %s", id, err)
+		}
+
+		billingItem, err := service.Id(id).GetBillingItem()
+		if err != nil {
+			return fmt.Errorf("Error getting billing item for network gateway member %d: %s", id, err)
+		}
+		if billingItem.Id == nil {
+			continue
+		}
+
+		// Gateway members are monthly servers, which only support an anniversary date cancellation.
+		_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelItem(
+			sl.Bool(false), sl.Bool(true), sl.String("No longer required"), sl.String("Please cancel this network gateway"),
+		)
+		if err != nil {
+			return fmt.Errorf("Error canceling network gateway member %d: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerNetworkGatewayExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	gatewayId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	gateway, err := services.GetNetworkGatewayService(sess).
+		Id(gatewayId).
+		Mask("id").
+		GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving network gateway: %s", err)
+	}
+
+	return gateway.Id != nil && *gateway.Id == gatewayId, nil
+}
+
+// Waits until the provisioned server is a member of a gateway, and returns the ID of the gateway.
+func waitForNetworkGateway(sess *session.Session, hardwareId int) (int, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			hardware, err := services.GetHardwareService(sess).
+				Id(hardwareId).
+				Mask("networkGatewayMember[networkGatewayId]").
+				GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if hardware.NetworkGatewayMember == nil || hardware.NetworkGatewayMember.NetworkGatewayId == nil {
+				return 0, "pending", nil
+			}
+
+			return *hardware.NetworkGatewayMember.NetworkGatewayId, "complete", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	gatewayId, err := stateConf.WaitForState()
+	if err != nil {
+		return 0, err
+	}
+
+	return gatewayId.(int), nil
+}
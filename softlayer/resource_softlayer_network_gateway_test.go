@@ -0,0 +1,182 @@
+package softlayer
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerNetworkGateway_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerNetworkGatewayConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway.gw", "name", "tfuatgw"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway.gw", "members.#", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway.gw", "members.0.hostname", "tfuatgw1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway.gw", "members.1.hostname", "tfuatgw2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway.gw", "member_ids.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_network_gateway.gw", "public_ip_address"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_network_gateway.gw", "private_ip_address"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_network_gateway.gw", "public_vlan_id"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_network_gateway.gw", "private_vlan_id"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerNetworkGatewayConfig_name_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway.gw", "name", "tfuatgw-updated"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "softlayer_network_gateway.gw",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"datacenter", "package_key_name", "process_key_name", "os_key_name", "memory",
+					"network_speed", "redundant_network", "unbonded_network", "private_network_only",
+					"tcp_monitoring", "public_bandwidth", "disk_key_names", "redundant_power_supply", "ssh_key_ids",
+				},
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerNetworkGateway_InvalidOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccCheckSoftLayerNetworkGatewayConfig_invalid_datacenter,
+				ExpectError: regexp.MustCompile("Invalid network gateway order"),
+			},
+		},
+	})
+}
+
+func TestFlattenNetworkGatewayMembers(t *testing.T) {
+	gatewayMembers := []datatypes.Network_Gateway_Member{
+		{
+			HardwareId: sl.Int(2),
+			Hardware:   &datatypes.Hardware{Hostname: sl.String("gw2"), Domain: sl.String("example.com")},
+		},
+		{
+			HardwareId: sl.Int(1),
+			Hardware:   &datatypes.Hardware{Hostname: sl.String("gw1"), Domain: sl.String("example.com")},
+		},
+		{
+			HardwareId: sl.Int(3),
+		},
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{"hostname": "gw1", "domain": "example.com"},
+		map[string]interface{}{"hostname": "gw2", "domain": "example.com"},
+	}
+
+	members, memberIds := flattenNetworkGatewayMembers(configured, gatewayMembers)
+	expectedMembers := []map[string]interface{}{
+		{"hostname": "gw1", "domain": "example.com"},
+		{"hostname": "gw2", "domain": "example.com"},
+	}
+	if !reflect.DeepEqual(members, expectedMembers) {
+		t.Errorf("Expected members %v, got %v", expectedMembers, members)
+	}
+	if !reflect.DeepEqual(memberIds, []int{1, 2}) {
+		t.Errorf("Expected member IDs [1 2], got %v", memberIds)
+	}
+
+	// Members which are not configured, like after an import, keep the order of the API.
+	members, memberIds = flattenNetworkGatewayMembers(nil, gatewayMembers)
+	if len(members) != 2 || members[0]["hostname"] != "gw2" {
+		t.Errorf("Expected the members in API order, got %v", members)
+	}
+	if !reflect.DeepEqual(memberIds, []int{2, 1}) {
+		t.Errorf("Expected member IDs [2 1], got %v", memberIds)
+	}
+}
+
+const testAccCheckSoftLayerNetworkGatewayConfig_basic = `
+resource "softlayer_network_gateway" "gw" {
+    name = "tfuatgw"
+    members = [
+        {
+            hostname = "tfuatgw1"
+            domain = "terraformuat.softlayer.com"
+        },
+        {
+            hostname = "tfuatgw2"
+            domain = "terraformuat.softlayer.com"
+        }
+    ]
+    datacenter = "ams01"
+    package_key_name = "2U_NETWORK_GATEWAY_APPLIANCE_1O_GBPS"
+    process_key_name = "INTEL_XEON_2620_2_40"
+    os_key_name = "OS_VYATTA_5600_5_X_UP_TO_1GBPS_SUBSCRIPTION_EDITION_64_BIT"
+    memory = 64
+    network_speed = 1000
+    disk_key_names = [ "HARD_DRIVE_2_00_TB_SATA_2" ]
+    redundant_network = true
+}
+`
+
+const testAccCheckSoftLayerNetworkGatewayConfig_name_update = `
+resource "softlayer_network_gateway" "gw" {
+    name = "tfuatgw-updated"
+    members = [
+        {
+            hostname = "tfuatgw1"
+            domain = "terraformuat.softlayer.com"
+        },
+        {
+            hostname = "tfuatgw2"
+            domain = "terraformuat.softlayer.com"
+        }
+    ]
+    datacenter = "ams01"
+    package_key_name = "2U_NETWORK_GATEWAY_APPLIANCE_1O_GBPS"
+    process_key_name = "INTEL_XEON_2620_2_40"
+    os_key_name = "OS_VYATTA_5600_5_X_UP_TO_1GBPS_SUBSCRIPTION_EDITION_64_BIT"
+    memory = 64
+    network_speed = 1000
+    disk_key_names = [ "HARD_DRIVE_2_00_TB_SATA_2" ]
+    redundant_network = true
+}
+`
+
+const testAccCheckSoftLayerNetworkGatewayConfig_invalid_datacenter = `
+resource "softlayer_network_gateway" "gw" {
+    name = "tfuatgw"
+    members = [
+        {
+            hostname = "tfuatgw1"
+            domain = "terraformuat.softlayer.com"
+        }
+    ]
+    datacenter = "nowhere01"
+    package_key_name = "2U_NETWORK_GATEWAY_APPLIANCE_1O_GBPS"
+    process_key_name = "INTEL_XEON_2620_2_40"
+    os_key_name = "OS_VYATTA_5600_5_X_UP_TO_1GBPS_SUBSCRIPTION_EDITION_64_BIT"
+    memory = 64
+}
+`
@@ -0,0 +1,233 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	networkGatewayVlanMask = "id,networkGatewayId,networkVlanId,bypassFlag"
+)
+
+func resourceSoftLayerNetworkGatewayVlanAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerNetworkGatewayVlanAssociationCreate,
+		Read:     resourceSoftLayerNetworkGatewayVlanAssociationRead,
+		Update:   resourceSoftLayerNetworkGatewayVlanAssociationUpdate,
+		Delete:   resourceSoftLayerNetworkGatewayVlanAssociationDelete,
+		Exists:   resourceSoftLayerNetworkGatewayVlanAssociationExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"gateway_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"network_vlan_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"bypass": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	gatewayId := d.Get("gateway_id").(int)
+	vlanId := d.Get("network_vlan_id").(int)
+
+	log.Printf("[INFO] Associating VLAN %d with network gateway %d", vlanId, gatewayId)
+
+	gatewayVlan, err := services.GetNetworkGatewayVlanService(sess).CreateObject(&datatypes.Network_Gateway_Vlan{
+		NetworkGatewayId: sl.Int(gatewayId),
+		NetworkVlanId:    sl.Int(vlanId),
+	})
+	if err != nil {
+		return fmt.Errorf("Error associating VLAN %d with network gateway %d: %s", vlanId, gatewayId, err)
+	}
+
+	d.SetId(strconv.Itoa(*gatewayVlan.Id))
+
+	log.Printf("[INFO] Network Gateway VLAN ID: %s", d.Id())
+
+	// Route the VLAN through the gateway, or around it, as configured.
+	bypass := d.Get("bypass").(bool)
+	if sl.Get(gatewayVlan.BypassFlag, !bypass).(bool) != bypass {
+		err = setNetworkGatewayVlanBypass(sess, gatewayId, *gatewayVlan.Id, bypass)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerNetworkGatewayVlanAssociationRead(d, meta)
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	gatewayVlan, err := services.GetNetworkGatewayVlanService(sess).
+		Id(id).
+		Mask(networkGatewayVlanMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving network gateway VLAN association: %s", err)
+	}
+
+	d.Set("gateway_id", sl.Get(gatewayVlan.NetworkGatewayId, nil))
+	d.Set("network_vlan_id", sl.Get(gatewayVlan.NetworkVlanId, nil))
+	d.Set("bypass", sl.Get(gatewayVlan.BypassFlag, false))
+
+	return nil
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("bypass") {
+		err = setNetworkGatewayVlanBypass(sess, d.Get("gateway_id").(int), id, d.Get("bypass").(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerNetworkGatewayVlanAssociationRead(d, meta)
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkGatewayVlanService(sess)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	err = service.Id(id).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error removing network gateway VLAN association %d: %s", id, err)
+	}
+
+	// The association is removed asynchronously.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			gatewayVlan, err := service.Id(id).Mask("id").GetObject()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return true, "complete", nil
+				}
+				return nil, "", err
+			}
+			return gatewayVlan, "pending", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for network gateway VLAN association %d to be removed: %s", id, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	gatewayVlan, err := services.GetNetworkGatewayVlanService(sess).
+		Id(id).
+		Mask("id").
+		GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving network gateway VLAN association: %s", err)
+	}
+
+	return gatewayVlan.Id != nil && *gatewayVlan.Id == id, nil
+}
+
+// Bypasses or unbypasses the associated VLAN, and waits until the gateway reports the new routing.
+func setNetworkGatewayVlanBypass(sess *session.Session, gatewayId int, gatewayVlanId int, bypass bool) error {
+	service := services.GetNetworkGatewayService(sess).Id(gatewayId)
+	vlans := []datatypes.Network_Gateway_Vlan{{Id: sl.Int(gatewayVlanId)}}
+
+	var err error
+	if bypass {
+		log.Printf("[INFO] Bypassing network gateway %d for VLAN association %d", gatewayId, gatewayVlanId)
+		err = service.BypassVlans(vlans)
+	} else {
+		log.Printf("[INFO] Routing VLAN association %d through network gateway %d", gatewayVlanId, gatewayId)
+		err = service.UnbypassVlans(vlans)
+	}
+	if err != nil {
+		return fmt.Errorf("Error changing the bypass of network gateway VLAN association %d: %s", gatewayVlanId, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			gatewayVlan, err := services.GetNetworkGatewayVlanService(sess).
+				Id(gatewayVlanId).
+				Mask("id,bypassFlag").
+				GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if sl.Get(gatewayVlan.BypassFlag, false).(bool) == bypass {
+				return gatewayVlan, "complete", nil
+			}
+			return gatewayVlan, "pending", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for the bypass of network gateway VLAN association %d: %s", gatewayVlanId, err)
+	}
+
+	return nil
+}
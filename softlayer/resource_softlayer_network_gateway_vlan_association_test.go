@@ -0,0 +1,81 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerNetworkGatewayVlanAssociation_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources(
+						"softlayer_network_gateway_vlan_association.assoc", "gateway_id",
+						"softlayer_network_gateway.gw", "id"),
+					testAccCheckSoftLayerResources(
+						"softlayer_network_gateway_vlan_association.assoc", "network_vlan_id",
+						"softlayer_vlan.vlan", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway_vlan_association.assoc", "bypass", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway_vlan_association.assoc", "bypass", "false"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "softlayer_network_gateway_vlan_association.assoc",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig(bypass bool) string {
+	bypassValue := "false"
+	if bypass {
+		bypassValue = "true"
+	}
+	return `
+resource "softlayer_network_gateway" "gw" {
+    name = "tfuatgwvlan"
+    members = [
+        {
+            hostname = "tfuatgwvlan1"
+            domain = "terraformuat.softlayer.com"
+        }
+    ]
+    datacenter = "ams01"
+    package_key_name = "2U_NETWORK_GATEWAY_APPLIANCE_1O_GBPS"
+    process_key_name = "INTEL_XEON_2620_2_40"
+    os_key_name = "OS_VYATTA_5600_5_X_UP_TO_1GBPS_SUBSCRIPTION_EDITION_64_BIT"
+    memory = 64
+    network_speed = 1000
+    disk_key_names = [ "HARD_DRIVE_2_00_TB_SATA_2" ]
+}
+
+resource "softlayer_vlan" "vlan" {
+    name = "tfuatgwvlan"
+    datacenter = "ams01"
+    type = "PRIVATE"
+    primary_subnet_size = 8
+}
+
+resource "softlayer_network_gateway_vlan_association" "assoc" {
+    gateway_id = "${softlayer_network_gateway.gw.id}"
+    network_vlan_id = "${softlayer_vlan.vlan.id}"
+    bypass = ` + bypassValue + `
+}
+`
+}
@@ -12,18 +12,31 @@ import (
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/filter"
 	"github.com/softlayer/softlayer-go/helpers/order"
+	"github.com/softlayer/softlayer-go/helpers/product"
 	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
 	"github.com/softlayer/softlayer-go/sl"
 )
 
+const (
+	objectStoragePackageType = "ADDITIONAL_SERVICES"
+	objectStorageItemKeyName = "OBJECT_STORAGE_PAY_AS_YOU_GO"
+	objectStorageAccountMask = "id,username,billingItem[id]"
+)
+
 func resourceSoftLayerObjectStorageAccount() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceSoftLayerObjectStorageAccountCreate,
-		Read:     resourceSoftLayerObjectStorageAccountRead,
-		Update:   resourceSoftLayerObjectStorageAccountUpdate,
-		Delete:   resourceSoftLayerObjectStorageAccountDelete,
-		Exists:   resourceSoftLayerObjectStorageAccountExists,
-		Importer: &schema.ResourceImporter{},
+		Create: resourceSoftLayerObjectStorageAccountCreate,
+		Read:   resourceSoftLayerObjectStorageAccountRead,
+		Update: resourceSoftLayerObjectStorageAccountUpdate,
+		Delete: resourceSoftLayerObjectStorageAccountDelete,
+		Exists: resourceSoftLayerObjectStorageAccountExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerObjectStorageAccountImport,
+		},
+
+		SchemaVersion: 1,
+		MigrateState:  resourceSoftLayerObjectStorageAccountMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -34,6 +47,65 @@ func resourceSoftLayerObjectStorageAccount() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"adopt_existing": &schema.Schema{
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				DiffSuppressFunc: applyOnce,
+			},
+			"adopted": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"api_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"endpoints": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"cdn_urls": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"http_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flash_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -42,53 +114,91 @@ func resourceSoftLayerObjectStorageAccountCreate(d *schema.ResourceData, meta in
 	sess := meta.(ProviderConfig).SoftLayerSession()
 	accountService := services.GetAccountService(sess)
 
-	// Check if an object storage account exists
-	objectStorageAccounts, err := accountService.GetHubNetworkStorage()
+	// Adopt an existing object storage account only if it is explicitly requested
+	if d.Get("adopt_existing").(bool) {
+		objectStorageAccounts, err := accountService.Mask(objectStorageAccountMask).GetHubNetworkStorage()
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on create: %s", err)
+		}
+
+		if len(objectStorageAccounts) > 0 {
+			log.Printf("[INFO] Adopting object storage account %s", *objectStorageAccounts[0].Username)
+			d.SetId(*objectStorageAccounts[0].Username)
+			d.Set("adopted", true)
+			return resourceSoftLayerObjectStorageAccountRead(d, meta)
+		}
+	}
+
+	// Order the account
+	productOrderContainer, err := buildObjectStorageAccountOrderContainer(sess)
 	if err != nil {
 		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on create: %s", err)
 	}
 
-	if len(objectStorageAccounts) == 0 {
-		// Order the account
-		productOrderService := services.GetProductOrderService(sess)
-
-		receipt, err := productOrderService.PlaceOrder(&datatypes.Container_Product_Order{
-			Quantity:  sl.Int(1),
-			PackageId: sl.Int(0),
-			Prices: []datatypes.Product_Item_Price{
-				{Id: sl.Int(30920)},
-			},
-		}, sl.Bool(false))
-		if err != nil {
-			return fmt.Errorf(
-				"resource_softlayer_objectstorage_account: Error ordering account: %s", err)
-		}
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf(
+			"resource_softlayer_objectstorage_account: Error ordering account: %s", err)
+	}
 
-		// Wait for the object storage account order to complete.
-		billingOrderItem, err := WaitForOrderCompletion(&receipt, meta)
-		if err != nil {
-			return fmt.Errorf(
-				"Error waiting for object storage account order (%d) to complete: %s", receipt.OrderId, err)
-		}
+	// Wait for the object storage account order to complete.
+	billingOrderItem, err := WaitForOrderCompletion(&receipt, meta)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for object storage account order (%d) to complete: %s", receipt.OrderId, err)
+	}
 
-		// Get accountName using filter on hub network storage
-		objectStorageAccounts, err = accountService.Filter(
-			filter.Path("billingItem.id").Eq(billingOrderItem.BillingItem.Id).Build(),
-		).GetNetworkStorage()
-		if err != nil {
-			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on retrieving new: %s", err)
-		}
+	// Get accountName using filter on hub network storage
+	objectStorageAccounts, err := accountService.Filter(
+		filter.Path("billingItem.id").Eq(billingOrderItem.BillingItem.Id).Build(),
+	).GetNetworkStorage()
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on retrieving new: %s", err)
+	}
 
-		if len(objectStorageAccounts) == 0 {
-			return fmt.Errorf("resource_softlayer_objectstorage_account: Failed to create object storage account.")
-		}
+	if len(objectStorageAccounts) == 0 {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Failed to create object storage account.")
 	}
 
 	// Get account name and set as the Id
 	d.SetId(*objectStorageAccounts[0].Username)
-	d.Set("name", *objectStorageAccounts[0].Username)
+	d.Set("adopted", false)
 
-	return nil
+	return resourceSoftLayerObjectStorageAccountRead(d, meta)
+}
+
+// Builds an order for an object storage account. The price is looked up by the item key name.
+func buildObjectStorageAccountOrderContainer(sess *session.Session) (datatypes.Container_Product_Order, error) {
+	pkg, err := product.GetPackageByType(sess, objectStoragePackageType)
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id, "id,keyName,prices[id,locationGroupId]")
+	if err != nil {
+		return datatypes.Container_Product_Order{}, err
+	}
+
+	for _, item := range productItems {
+		if item.KeyName == nil || *item.KeyName != objectStorageItemKeyName {
+			continue
+		}
+		// Use the standard price, which has no location group.
+		for _, price := range item.Prices {
+			if price.LocationGroupId == nil {
+				return datatypes.Container_Product_Order{
+					Quantity:  sl.Int(1),
+					PackageId: pkg.Id,
+					Prices: []datatypes.Product_Item_Price{
+						{Id: price.Id},
+					},
+				}, nil
+			}
+		}
+	}
+
+	return datatypes.Container_Product_Order{},
+		fmt.Errorf("No product items matching %s could be found", objectStorageItemKeyName)
 }
 
 func WaitForOrderCompletion(
@@ -125,36 +235,97 @@ func WaitForOrderCompletion(
 	return *billingOrderItem, err
 }
 
+// Imports an existing object storage account. The ID is the account name. An imported account was not ordered
+// by Terraform, so it is treated as adopted and isn't cancelled when the resource is destroyed.
+func resourceSoftLayerObjectStorageAccountImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("adopt_existing", true)
+	d.Set("adopted", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceSoftLayerObjectStorageAccountRead(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(ProviderConfig).SoftLayerSession()
-	accountService := services.GetAccountService(sess)
 	accountName := d.Id()
 	d.Set("name", accountName)
 
-	// Check if an object storage account exists
-	objectStorageAccounts, err := accountService.Filter(
-		filter.Path("username").Eq(accountName).Build(),
-	).GetHubNetworkStorage()
+	objectStorageAccount, err := getObjectStorageAccount(sess, accountName)
+	if err != nil {
+		return err
+	}
+
+	storageService := services.GetNetworkStorageService(sess).Id(*objectStorageAccount.Id)
+
+	username, apiKey, err := getObjectStorageCredentials(sess, accountName, *objectStorageAccount.Id)
 	if err != nil {
 		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on Read: %s", err)
 	}
+	d.Set("username", username)
+	d.Set("api_key", apiKey)
 
-	for _, objectStorageAccount := range objectStorageAccounts {
-		if *objectStorageAccount.Username == accountName {
-			return nil
-		}
+	connections, err := storageService.GetObjectStorageConnectionInformation()
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on Read: %s", err)
+	}
+	endpoints := make([]map[string]interface{}, 0, len(connections))
+	for _, connection := range connections {
+		endpoints = append(endpoints, map[string]interface{}{
+			"datacenter":       sl.Get(connection.DatacenterShortName, ""),
+			"public_endpoint":  sl.Get(connection.PublicEndpoint, ""),
+			"private_endpoint": sl.Get(connection.PrivateEndpoint, ""),
+		})
 	}
+	d.Set("endpoints", endpoints)
 
-	return fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", accountName)
+	cdnUrls, err := storageService.GetCdnUrls()
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on Read: %s", err)
+	}
+	cdns := make([]map[string]interface{}, 0, len(cdnUrls))
+	for _, cdnUrl := range cdnUrls {
+		cdns = append(cdns, map[string]interface{}{
+			"datacenter": sl.Get(cdnUrl.Datacenter, ""),
+			"http_url":   sl.Get(cdnUrl.HttpUrl, ""),
+			"flash_url":  sl.Get(cdnUrl.FlashUrl, ""),
+		})
+	}
+	d.Set("cdn_urls", cdns)
+
+	return nil
 }
 
 func resourceSoftLayerObjectStorageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	// Nothing to update for now. Not supported.
+	// Only local_note can be updated and it is kept in the state.
 	return nil
 }
 
 func resourceSoftLayerObjectStorageAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	// Delete is not supported for now.
+	// An adopted account was not ordered by this resource and is left as it is.
+	if d.Get("adopted").(bool) {
+		log.Printf("[INFO] Object storage account %s was adopted and is not cancelled", d.Id())
+		return nil
+	}
+
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	objectStorageAccount, err := getObjectStorageAccount(sess, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if objectStorageAccount.BillingItem == nil || objectStorageAccount.BillingItem.Id == nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: No billing item for account %s", d.Id())
+	}
+
+	success, err := services.GetBillingItemService(sess).Id(*objectStorageAccount.BillingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on cancellation: %s", err)
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful cancellation")
+	}
+
 	return nil
 }
 
@@ -170,3 +341,21 @@ func resourceSoftLayerObjectStorageAccountExists(d *schema.ResourceData, meta in
 
 	return true, nil
 }
+
+func getObjectStorageAccount(sess *session.Session, accountName string) (datatypes.Network_Storage, error) {
+	objectStorageAccounts, err := services.GetAccountService(sess).
+		Mask(objectStorageAccountMask).
+		Filter(filter.Path("hubNetworkStorage.username").Eq(accountName).Build()).
+		GetHubNetworkStorage()
+	if err != nil {
+		return datatypes.Network_Storage{}, fmt.Errorf("resource_softlayer_objectstorage_account: Error on Read: %s", err)
+	}
+
+	for _, objectStorageAccount := range objectStorageAccounts {
+		if *objectStorageAccount.Username == accountName {
+			return objectStorageAccount, nil
+		}
+	}
+
+	return datatypes.Network_Storage{}, fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", accountName)
+}
@@ -0,0 +1,40 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func resourceSoftLayerObjectStorageAccountMigrateState(
+	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found SoftLayer Object Storage Account State v0; migrating to v1")
+		return migrateObjectStorageAccountStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// Version 0 of the resource always adopted an existing object storage account of the SoftLayer account.
+// Such accounts are usually shared, and are marked as adopted so that they are not cancelled on destroy.
+func migrateObjectStorageAccountStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	if _, ok := is.Attributes["adopted"]; !ok {
+		is.Attributes["adopted"] = "true"
+	}
+	if _, ok := is.Attributes["adopt_existing"]; !ok {
+		is.Attributes["adopt_existing"] = "false"
+	}
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
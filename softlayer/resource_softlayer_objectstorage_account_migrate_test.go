@@ -0,0 +1,56 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSoftLayerObjectStorageAccountMigrateState(t *testing.T) {
+	cases := map[string]struct {
+		StateVersion int
+		Attributes   map[string]string
+		Expected     map[string]string
+	}{
+		"v0_1_adopted": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"username": "SLOS123-2",
+			},
+			Expected: map[string]string{
+				"adopted":        "true",
+				"adopt_existing": "false",
+			},
+		},
+		"v0_1_ordered": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"username":       "SLOS123-2",
+				"adopted":        "false",
+				"adopt_existing": "false",
+			},
+			Expected: map[string]string{
+				"adopted":        "false",
+				"adopt_existing": "false",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		is := &terraform.InstanceState{
+			ID:         "SLOS123-2",
+			Attributes: tc.Attributes,
+		}
+		is, err := resourceSoftLayerObjectStorageAccountMigrateState(tc.StateVersion, is, nil)
+		if err != nil {
+			t.Fatalf("bad: %s, err: %#v", tn, err)
+		}
+
+		for k, v := range tc.Expected {
+			if is.Attributes[k] != v {
+				t.Fatalf("bad: %s\n\n expected: %#v -> %#v\n got: %#v -> %#v\n in: %#v",
+					tn, k, v, k, is.Attributes[k], is.Attributes)
+			}
+		}
+	}
+}
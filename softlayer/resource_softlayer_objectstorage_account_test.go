@@ -21,8 +21,33 @@ func TestAccSoftLayerObjectStorageAccount_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSoftLayerObjectStorageAccountExists("softlayer_objectstorage_account.testacc_foobar", &accountName),
 					testAccCheckSoftLayerObjectStorageAccountAttributes(&accountName),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "adopted", "false"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "username"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "api_key"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "endpoints.0.public_endpoint"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "endpoints.0.private_endpoint"),
 				),
 			},
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageAccountConfig_adopt,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_account.adopted", "adopted", "true"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.adopted", "name"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "softlayer_objectstorage_account.adopted",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -63,3 +88,12 @@ func testAccCheckSoftLayerObjectStorageAccountAttributes(accountName *string) re
 var testAccCheckSoftLayerObjectStorageAccountConfig_basic = `
 resource "softlayer_objectstorage_account" "testacc_foobar" {
 }`
+
+var testAccCheckSoftLayerObjectStorageAccountConfig_adopt = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+}
+
+resource "softlayer_objectstorage_account" "adopted" {
+    adopt_existing = true
+    depends_on = ["softlayer_objectstorage_account.testacc_foobar"]
+}`
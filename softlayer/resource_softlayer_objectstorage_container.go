@@ -0,0 +1,290 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	swiftContainerMetaPrefix = "X-Container-Meta-"
+	swiftQuotaBytes          = "quota-bytes"
+	swiftQuotaCount          = "quota-count"
+)
+
+func resourceSoftLayerObjectStorageContainer() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerObjectStorageContainerCreate,
+		Read:     resourceSoftLayerObjectStorageContainerRead,
+		Update:   resourceSoftLayerObjectStorageContainerUpdate,
+		Delete:   resourceSoftLayerObjectStorageContainerDelete,
+		Exists:   resourceSoftLayerObjectStorageContainerExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					name := v.(string)
+					if name == "" || len(name) > 256 || strings.Contains(name, "/") {
+						errors = append(errors, fmt.Errorf(
+							"%s must be 1 to 256 characters long and can't contain '/'", k))
+					}
+					return
+				},
+			},
+
+			"read_acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"write_acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"quota_bytes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"quota_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateSwiftMetadata,
+			},
+
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"object_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"bytes_used": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerObjectStorageContainerCreate(d *schema.ResourceData, meta interface{}) error {
+	accountName := d.Get("account_name").(string)
+	datacenter := d.Get("datacenter").(string)
+	name := d.Get("name").(string)
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating object storage container %s in %s", name, datacenter)
+
+	err = client.PutContainer(name, objectStorageContainerHeaders(d))
+	if err != nil {
+		return fmt.Errorf("Error creating object storage container %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", accountName, datacenter, name))
+
+	return resourceSoftLayerObjectStorageContainerRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageContainerRead(d *schema.ResourceData, meta interface{}) error {
+	accountName, datacenter, name, err := parseObjectStorageContainerId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	headers, err := client.HeadContainer(name)
+	if err != nil {
+		if isSwiftNotFound(err) {
+			log.Printf("[WARN] Object storage container %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving object storage container %s: %s", name, err)
+	}
+
+	d.Set("account_name", accountName)
+	d.Set("datacenter", datacenter)
+	d.Set("name", name)
+	d.Set("read_acl", headers.Get("X-Container-Read"))
+	d.Set("write_acl", headers.Get("X-Container-Write"))
+
+	metadata := swiftMetadata(headers, swiftContainerMetaPrefix)
+	quotaBytes, _ := strconv.Atoi(metadata[swiftQuotaBytes])
+	quotaCount, _ := strconv.Atoi(metadata[swiftQuotaCount])
+	delete(metadata, swiftQuotaBytes)
+	delete(metadata, swiftQuotaCount)
+	d.Set("quota_bytes", quotaBytes)
+	d.Set("quota_count", quotaCount)
+	d.Set("metadata", metadata)
+
+	objectCount, _ := strconv.Atoi(headers.Get("X-Container-Object-Count"))
+	bytesUsed, _ := strconv.Atoi(headers.Get("X-Container-Bytes-Used"))
+	d.Set("object_count", objectCount)
+	d.Set("bytes_used", bytesUsed)
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageContainerUpdate(d *schema.ResourceData, meta interface{}) error {
+	accountName, datacenter, name, err := parseObjectStorageContainerId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	headers := objectStorageContainerHeaders(d)
+
+	// Removed values have to be removed explicitly. Otherwise Swift keeps them.
+	if d.Get("read_acl").(string) == "" {
+		headers["X-Remove-Container-Read"] = "x"
+	}
+	if d.Get("write_acl").(string) == "" {
+		headers["X-Remove-Container-Write"] = "x"
+	}
+	if d.Get("quota_bytes").(int) == 0 {
+		headers["X-Remove-Container-Meta-Quota-Bytes"] = "x"
+	}
+	if d.Get("quota_count").(int) == 0 {
+		headers["X-Remove-Container-Meta-Quota-Count"] = "x"
+	}
+	oldMetadata, newMetadata := d.GetChange("metadata")
+	for k := range oldMetadata.(map[string]interface{}) {
+		if _, ok := newMetadata.(map[string]interface{})[k]; !ok {
+			headers["X-Remove-Container-Meta-"+k] = "x"
+		}
+	}
+
+	err = client.PostContainer(name, headers)
+	if err != nil {
+		return fmt.Errorf("Error updating object storage container %s: %s", name, err)
+	}
+
+	return resourceSoftLayerObjectStorageContainerRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageContainerDelete(d *schema.ResourceData, meta interface{}) error {
+	accountName, datacenter, name, err := parseObjectStorageContainerId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	// Swift doesn't delete containers with objects.
+	if d.Get("force_destroy").(bool) {
+		objects, err := client.ListObjects(name)
+		if err != nil {
+			return fmt.Errorf("Error listing objects of object storage container %s: %s", name, err)
+		}
+		for _, object := range objects {
+			err = client.DeleteObject(name, object)
+			if err != nil && !isSwiftNotFound(err) {
+				return fmt.Errorf("Error deleting object %s of object storage container %s: %s", object, name, err)
+			}
+		}
+	}
+
+	err = client.DeleteContainer(name)
+	if err != nil && !isSwiftNotFound(err) {
+		return fmt.Errorf("Error deleting object storage container %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageContainerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	accountName, datacenter, name, err := parseObjectStorageContainerId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.HeadContainer(name)
+	if err != nil {
+		if isSwiftNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving object storage container %s: %s", name, err)
+	}
+	return true, nil
+}
+
+// Parses an ID of the form <account name>/<datacenter>/<container name>.
+func parseObjectStorageContainerId(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf(
+			"Not a valid object storage container ID, must be <account name>/<datacenter>/<container name>: %s", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func objectStorageContainerHeaders(d *schema.ResourceData) map[string]string {
+	headers := map[string]string{}
+
+	if readAcl := d.Get("read_acl").(string); readAcl != "" {
+		headers["X-Container-Read"] = readAcl
+	}
+	if writeAcl := d.Get("write_acl").(string); writeAcl != "" {
+		headers["X-Container-Write"] = writeAcl
+	}
+	if quotaBytes := d.Get("quota_bytes").(int); quotaBytes > 0 {
+		headers[swiftContainerMetaPrefix+"Quota-Bytes"] = strconv.Itoa(quotaBytes)
+	}
+	if quotaCount := d.Get("quota_count").(int); quotaCount > 0 {
+		headers[swiftContainerMetaPrefix+"Quota-Count"] = strconv.Itoa(quotaCount)
+	}
+	for k, v := range d.Get("metadata").(map[string]interface{}) {
+		headers[swiftContainerMetaPrefix+k] = v.(string)
+	}
+
+	return headers
+}
@@ -0,0 +1,146 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSoftLayerObjectStorageContainer_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerObjectStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerObjectStorageContainerConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerObjectStorageContainerExists("softlayer_objectstorage_container.bootstrap"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "name", "terraform-test-bootstrap"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "read_acl", ".r:*"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "quota_bytes", "1048576"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "metadata.owner", "ops"),
+				),
+			},
+
+			{
+				Config: testAccCheckSoftLayerObjectStorageContainerConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerObjectStorageContainerExists("softlayer_objectstorage_container.bootstrap"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "read_acl", ""),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "quota_bytes", "0"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "quota_count", "100"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "metadata.%", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.bootstrap", "metadata.team", "platform"),
+				),
+			},
+
+			{
+				ResourceName:            "softlayer_objectstorage_container.bootstrap",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy"},
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerObjectStorageContainerDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_objectstorage_container" {
+			continue
+		}
+
+		accountName, datacenter, name, err := parseObjectStorageContainerId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta(), accountName, datacenter)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.HeadContainer(name)
+		if err == nil {
+			return fmt.Errorf("Object storage container %s still exists", rs.Primary.ID)
+		}
+		if !isSwiftNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerObjectStorageContainerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		accountName, datacenter, name, err := parseObjectStorageContainerId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta(), accountName, datacenter)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.HeadContainer(name)
+		return err
+	}
+}
+
+const testAccCheckSoftLayerObjectStorageContainerConfig_basic = `
+resource "softlayer_objectstorage_account" "account" {
+    adopt_existing = true
+}
+
+resource "softlayer_objectstorage_container" "bootstrap" {
+    account_name = "${softlayer_objectstorage_account.account.id}"
+    datacenter = "dal05"
+    name = "terraform-test-bootstrap"
+    read_acl = ".r:*"
+    quota_bytes = 1048576
+    metadata = {
+        owner = "ops"
+    }
+}
+`
+
+const testAccCheckSoftLayerObjectStorageContainerConfig_update = `
+resource "softlayer_objectstorage_account" "account" {
+    adopt_existing = true
+}
+
+resource "softlayer_objectstorage_container" "bootstrap" {
+    account_name = "${softlayer_objectstorage_account.account.id}"
+    datacenter = "dal05"
+    name = "terraform-test-bootstrap"
+    quota_count = 100
+    metadata = {
+        team = "platform"
+    }
+    force_destroy = true
+}
+`
@@ -0,0 +1,296 @@
+package softlayer
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const swiftObjectMetaPrefix = "X-Object-Meta-"
+
+func resourceSoftLayerObjectStorageObject() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceSoftLayerObjectStorageObjectCreate,
+		Read:          resourceSoftLayerObjectStorageObjectRead,
+		Update:        resourceSoftLayerObjectStorageObjectUpdate,
+		Delete:        resourceSoftLayerObjectStorageObjectDelete,
+		Exists:        resourceSoftLayerObjectStorageObjectExists,
+		CustomizeDiff: diffObjectStorageObjectContent,
+		Importer:      &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"container": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source"},
+			},
+
+			"source": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content"},
+			},
+
+			"content_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateSwiftMetadata,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerObjectStorageObjectCreate(d *schema.ResourceData, meta interface{}) error {
+	accountName := d.Get("account_name").(string)
+	datacenter := d.Get("datacenter").(string)
+	container := d.Get("container").(string)
+	name := d.Get("name").(string)
+
+	err := putObjectStorageObject(d, meta, accountName, datacenter, container, name)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", accountName, datacenter, container, name))
+
+	return resourceSoftLayerObjectStorageObjectRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageObjectRead(d *schema.ResourceData, meta interface{}) error {
+	accountName, datacenter, container, name, err := parseObjectStorageObjectId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	headers, err := client.HeadObject(container, name)
+	if err != nil {
+		if isSwiftNotFound(err) {
+			log.Printf("[WARN] Object storage object %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving object storage object %s: %s", name, err)
+	}
+
+	d.Set("account_name", accountName)
+	d.Set("datacenter", datacenter)
+	d.Set("container", container)
+	d.Set("name", name)
+	d.Set("content_type", headers.Get("Content-Type"))
+	d.Set("metadata", swiftMetadata(headers, swiftObjectMetaPrefix))
+
+	// The ETag is the MD5 hash of the content. A change outside of Terraform is detected as a difference
+	// from the hash of the configured content.
+	d.Set("etag", strings.Trim(headers.Get("Etag"), "\""))
+
+	contentLength, _ := strconv.Atoi(headers.Get("Content-Length"))
+	d.Set("content_length", contentLength)
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageObjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	accountName, datacenter, container, name, err := parseObjectStorageObjectId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("content") || d.HasChange("source") || d.HasChange("etag") {
+		err = putObjectStorageObject(d, meta, accountName, datacenter, container, name)
+		if err != nil {
+			return err
+		}
+	} else if d.HasChange("content_type") || d.HasChange("metadata") {
+		client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+		if err != nil {
+			return err
+		}
+
+		// POST replaces all metadata of the object.
+		err = client.PostObject(container, name, objectStorageObjectHeaders(d))
+		if err != nil {
+			return fmt.Errorf("Error updating object storage object %s: %s", name, err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageObjectRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageObjectDelete(d *schema.ResourceData, meta interface{}) error {
+	accountName, datacenter, container, name, err := parseObjectStorageObjectId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteObject(container, name)
+	if err != nil && !isSwiftNotFound(err) {
+		return fmt.Errorf("Error deleting object storage object %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageObjectExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	accountName, datacenter, container, name, err := parseObjectStorageObjectId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.HeadObject(container, name)
+	if err != nil {
+		if isSwiftNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving object storage object %s: %s", name, err)
+	}
+	return true, nil
+}
+
+// Marks the object for upload when the hash of the configured content differs from the ETag of the object.
+func diffObjectStorageObjectContent(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("content") || !d.NewValueKnown("source") {
+		return d.SetNewComputed("etag")
+	}
+
+	hash, err := objectStorageObjectHash(d.Get("content").(string), d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	if hash != d.Get("etag").(string) {
+		return d.SetNew("etag", hash)
+	}
+
+	return nil
+}
+
+// Parses an ID of the form <account name>/<datacenter>/<container name>/<object name>.
+// Object names can contain slashes.
+func parseObjectStorageObjectId(id string) (string, string, string, string, error) {
+	parts := strings.SplitN(id, "/", 4)
+	if len(parts) != 4 {
+		return "", "", "", "", fmt.Errorf(
+			"Not a valid object storage object ID, must be <account name>/<datacenter>/<container name>/<object name>: %s", id)
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
+
+func putObjectStorageObject(d *schema.ResourceData, meta interface{}, accountName, datacenter, container, name string) error {
+	client, err := getObjectStorageSwiftClient(meta, accountName, datacenter)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if source := d.Get("source").(string); source != "" {
+		file, err := os.Open(source)
+		if err != nil {
+			return fmt.Errorf("Error opening object source %s: %s", source, err)
+		}
+		defer file.Close()
+		body = file
+	} else {
+		body = bytes.NewReader([]byte(d.Get("content").(string)))
+	}
+
+	log.Printf("[INFO] Uploading object storage object %s to container %s", name, container)
+
+	_, err = client.PutObject(container, name, body, objectStorageObjectHeaders(d))
+	if err != nil {
+		return fmt.Errorf("Error uploading object storage object %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func objectStorageObjectHeaders(d *schema.ResourceData) map[string]string {
+	headers := map[string]string{}
+
+	if contentType := d.Get("content_type").(string); contentType != "" {
+		headers["Content-Type"] = contentType
+	}
+	for k, v := range d.Get("metadata").(map[string]interface{}) {
+		headers[swiftObjectMetaPrefix+k] = v.(string)
+	}
+
+	return headers
+}
+
+// Returns the MD5 hash of the content, or of the file at source.
+func objectStorageObjectHash(content string, source string) (string, error) {
+	if source == "" {
+		hash := md5.Sum([]byte(content))
+		return hex.EncodeToString(hash[:]), nil
+	}
+
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return "", fmt.Errorf("Error reading object source %s: %s", source, err)
+	}
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,141 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSoftLayerObjectStorageObject_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerObjectStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerObjectStorageObjectConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerObjectStorageObjectExists("softlayer_objectstorage_object.init"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.init", "name", "scripts/init.sh"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.init", "content_type", "text/x-sh"),
+					// MD5 hash of the content
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.init", "etag", "e73054b37e607170aaa07b07be48b047"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.init", "metadata.version", "1"),
+				),
+			},
+
+			{
+				Config: testAccCheckSoftLayerObjectStorageObjectConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerObjectStorageObjectExists("softlayer_objectstorage_object.init"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.init", "content_length", "21"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.init", "metadata.version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerObjectStorageObjectDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_objectstorage_object" {
+			continue
+		}
+
+		accountName, datacenter, container, name, err := parseObjectStorageObjectId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta(), accountName, datacenter)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.HeadObject(container, name)
+		if err == nil {
+			return fmt.Errorf("Object storage object %s still exists", rs.Primary.ID)
+		}
+		if !isSwiftNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerObjectStorageObjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		accountName, datacenter, container, name, err := parseObjectStorageObjectId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta(), accountName, datacenter)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.HeadObject(container, name)
+		return err
+	}
+}
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_container = `
+resource "softlayer_objectstorage_account" "account" {
+    adopt_existing = true
+}
+
+resource "softlayer_objectstorage_container" "bootstrap" {
+    account_name = "${softlayer_objectstorage_account.account.id}"
+    datacenter = "dal05"
+    name = "terraform-test-objects"
+    force_destroy = true
+}
+`
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_basic = testAccCheckSoftLayerObjectStorageObjectConfig_container + `
+resource "softlayer_objectstorage_object" "init" {
+    account_name = "${softlayer_objectstorage_account.account.id}"
+    datacenter = "dal05"
+    container = "${softlayer_objectstorage_container.bootstrap.name}"
+    name = "scripts/init.sh"
+    content = "#!/bin/sh\necho init\n"
+    content_type = "text/x-sh"
+    metadata = {
+        version = "1"
+    }
+}
+`
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_update = testAccCheckSoftLayerObjectStorageObjectConfig_container + `
+resource "softlayer_objectstorage_object" "init" {
+    account_name = "${softlayer_objectstorage_account.account.id}"
+    datacenter = "dal05"
+    container = "${softlayer_objectstorage_container.bootstrap.name}"
+    name = "scripts/init.sh"
+    content = "#!/bin/sh\necho hello\n"
+    content_type = "text/x-sh"
+    metadata = {
+        version = "2"
+    }
+}
+`
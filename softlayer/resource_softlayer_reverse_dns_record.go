@@ -0,0 +1,205 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerReverseDnsRecord() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerReverseDnsRecordCreate,
+		Read:     resourceSoftLayerReverseDnsRecordRead,
+		Update:   resourceSoftLayerReverseDnsRecordUpdate,
+		Delete:   resourceSoftLayerReverseDnsRecordDelete,
+		Exists:   resourceSoftLayerReverseDnsRecordExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"ip_address": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					address := v.(string)
+					if net.ParseIP(address) == nil {
+						errors = append(errors, fmt.Errorf("Invalid IP format: %s", address))
+					}
+					return
+				},
+				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+					newIpAddress := net.ParseIP(n)
+					// Return true when the compressed value of n equals the compressed value of o.
+					return newIpAddress != nil && (newIpAddress.String() == net.ParseIP(o).String())
+				},
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+					return strings.TrimSuffix(o, ".") == strings.TrimSuffix(n, ".")
+				},
+			},
+
+			"ttl": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  86400,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerReverseDnsRecordCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetDnsDomainService(sess)
+
+	ipAddress := d.Get("ip_address").(string)
+	hostname := d.Get("hostname").(string)
+
+	log.Printf("[INFO] Creating reverse DNS record for %s", ipAddress)
+
+	record, err := service.CreatePtrRecord(sl.String(ipAddress), sl.String(hostname), sl.Int(d.Get("ttl").(int)))
+	if err != nil {
+		return fmt.Errorf("Error creating reverse DNS record for %s: %s", ipAddress, err)
+	}
+
+	d.SetId(strconv.Itoa(*record.Id))
+	log.Printf("[INFO] Reverse DNS record ID: %s", d.Id())
+
+	return resourceSoftLayerReverseDnsRecordRead(d, meta)
+}
+
+func resourceSoftLayerReverseDnsRecordRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	record, err := service.Id(id).Mask("id,host,data,ttl,domain[name]").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving reverse DNS record: %s", err)
+	}
+
+	if record.Domain != nil && record.Domain.Name != nil {
+		ipAddress, err := parseReverseDnsName(*record.Host, *record.Domain.Name)
+		if err != nil {
+			return err
+		}
+		d.Set("ip_address", ipAddress)
+	}
+	d.Set("hostname", sl.Get(record.Data, nil))
+	d.Set("ttl", sl.Get(record.Ttl, nil))
+
+	return nil
+}
+
+func resourceSoftLayerReverseDnsRecordUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetDnsDomainService(sess)
+
+	ipAddress := d.Get("ip_address").(string)
+
+	// createPtrRecord replaces the existing PTR record of the IP address.
+	record, err := service.CreatePtrRecord(
+		sl.String(ipAddress), sl.String(d.Get("hostname").(string)), sl.Int(d.Get("ttl").(int)))
+	if err != nil {
+		return fmt.Errorf("Error updating reverse DNS record for %s: %s", ipAddress, err)
+	}
+
+	d.SetId(strconv.Itoa(*record.Id))
+
+	return resourceSoftLayerReverseDnsRecordRead(d, meta)
+}
+
+func resourceSoftLayerReverseDnsRecordDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = service.Id(id).DeleteObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting reverse DNS record: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerReverseDnsRecordExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	record, err := service.Id(id).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving reverse DNS record: %s", err)
+	}
+
+	return record.Id != nil && *record.Id == id, nil
+}
+
+// Returns the IP address of a PTR record from its host and the name of its reverse zone. For example,
+// host 4 in 3.2.1.in-addr.arpa is 1.2.3.4. IPv6 zones and hosts consist of nibbles in reverse order.
+func parseReverseDnsName(host string, zone string) (string, error) {
+	name := strings.TrimSuffix(host+"."+zone, ".")
+
+	switch {
+	case strings.HasSuffix(name, ".in-addr.arpa"):
+		octets := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa"), ".")
+		if len(octets) != 4 {
+			return "", fmt.Errorf("Not a valid IPv4 reverse DNS name: %s", name)
+		}
+		for i, j := 0, len(octets)-1; i < j; i, j = i+1, j-1 {
+			octets[i], octets[j] = octets[j], octets[i]
+		}
+		ip := net.ParseIP(strings.Join(octets, "."))
+		if ip == nil {
+			return "", fmt.Errorf("Not a valid IPv4 reverse DNS name: %s", name)
+		}
+		return ip.String(), nil
+
+	case strings.HasSuffix(name, ".ip6.arpa"):
+		nibbles := strings.Split(strings.TrimSuffix(name, ".ip6.arpa"), ".")
+		if len(nibbles) != 32 {
+			return "", fmt.Errorf("Not a valid IPv6 reverse DNS name: %s", name)
+		}
+		address := ""
+		for i := len(nibbles) - 1; i >= 0; i-- {
+			address += nibbles[i]
+			if i%4 == 0 && i > 0 {
+				address += ":"
+			}
+		}
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return "", fmt.Errorf("Not a valid IPv6 reverse DNS name: %s", name)
+		}
+		return ip.String(), nil
+	}
+
+	return "", fmt.Errorf("Not a valid reverse DNS name: %s", name)
+}
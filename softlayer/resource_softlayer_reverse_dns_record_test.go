@@ -0,0 +1,134 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccSoftLayerReverseDnsRecord_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerReverseDnsRecordConfig_basic, "mail.example.com", 900),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerReverseDnsRecordExists("softlayer_reverse_dns_record.mail"),
+					testAccCheckSoftLayerResources("softlayer_reverse_dns_record.mail", "ip_address",
+						"softlayer_virtual_guest.mailvm", "ipv4_address"),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.mail", "hostname", "mail.example.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.mail", "ttl", "900"),
+					testAccCheckSoftLayerReverseDnsRecordExists("softlayer_reverse_dns_record.mail6"),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.mail6", "hostname", "mail.example.com"),
+				),
+			},
+
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerReverseDnsRecordConfig_basic, "smtp.example.com", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.mail", "hostname", "smtp.example.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.mail", "ttl", "3600"),
+				),
+			},
+
+			{
+				ResourceName:      "softlayer_reverse_dns_record.mail",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseReverseDnsName(t *testing.T) {
+	cases := []struct {
+		host, zone, ipAddress string
+	}{
+		{"4", "3.2.1.in-addr.arpa", "1.2.3.4"},
+		{"4.3", "2.1.in-addr.arpa.", "1.2.3.4"},
+		{"3.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0", "2.3.0.0.1.0.5.1.0.0.9.c.1.0.4.2.ip6.arpa", "2401:c900:1501:32::3"},
+	}
+
+	for _, c := range cases {
+		ipAddress, err := parseReverseDnsName(c.host, c.zone)
+		if err != nil {
+			t.Fatalf("parseReverseDnsName(%q, %q) returned an error: %s", c.host, c.zone, err)
+		}
+		if ipAddress != c.ipAddress {
+			t.Fatalf("parseReverseDnsName(%q, %q) = %q, expected %q", c.host, c.zone, ipAddress, c.ipAddress)
+		}
+	}
+
+	if _, err := parseReverseDnsName("www", "example.com"); err == nil {
+		t.Fatal("parseReverseDnsName accepted a forward DNS name")
+	}
+}
+
+func testAccCheckSoftLayerReverseDnsRecordExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		service := services.GetDnsDomainResourceRecordService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+		record, err := service.Id(id).GetObject()
+		if err != nil {
+			return err
+		}
+
+		if *record.Id != id {
+			return fmt.Errorf("Reverse DNS record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerReverseDnsRecordConfig_basic = `
+resource "softlayer_virtual_guest" "mailvm" {
+    hostname = "mailvm"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    ipv6_enabled = true
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_reverse_dns_record" "mail" {
+    ip_address = "${softlayer_virtual_guest.mailvm.ipv4_address}"
+    hostname = "%s"
+    ttl = %d
+}
+
+resource "softlayer_reverse_dns_record" "mail6" {
+    ip_address = "${softlayer_virtual_guest.mailvm.ipv6_address}"
+    hostname = "mail.example.com"
+}
+`
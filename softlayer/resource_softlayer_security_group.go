@@ -0,0 +1,140 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerSecurityGroupCreate,
+		Read:     resourceSoftLayerSecurityGroupRead,
+		Update:   resourceSoftLayerSecurityGroupUpdate,
+		Delete:   resourceSoftLayerSecurityGroupDelete,
+		Exists:   resourceSoftLayerSecurityGroupExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerSecurityGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	template := datatypes.Network_SecurityGroup{
+		Name: sl.String(d.Get("name").(string)),
+	}
+	if description, ok := d.GetOk("description"); ok {
+		template.Description = sl.String(description.(string))
+	}
+
+	groups, err := service.CreateObjects([]datatypes.Network_SecurityGroup{template})
+	if err != nil {
+		return fmt.Errorf("Error creating security group: %s", err)
+	}
+	if len(groups) != 1 || groups[0].Id == nil {
+		return fmt.Errorf("Error creating security group: expected one security group, got %d", len(groups))
+	}
+
+	d.SetId(strconv.Itoa(*groups[0].Id))
+	log.Printf("[INFO] Security group ID: %s", d.Id())
+
+	return resourceSoftLayerSecurityGroupRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	group, err := service.Id(groupId).Mask("id,name,description").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving security group: %s", err)
+	}
+
+	d.Set("name", sl.Get(group.Name, nil))
+	d.Set("description", sl.Get(group.Description, nil))
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = service.EditObjects([]datatypes.Network_SecurityGroup{
+		{
+			Id:          sl.Int(groupId),
+			Name:        sl.String(d.Get("name").(string)),
+			Description: sl.String(d.Get("description").(string)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating security group: %s", err)
+	}
+
+	return resourceSoftLayerSecurityGroupRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = service.DeleteObjects([]datatypes.Network_SecurityGroup{{Id: sl.Int(groupId)}})
+	if err != nil {
+		return fmt.Errorf("Error deleting security group: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	group, err := service.Id(groupId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving security group: %s", err)
+	}
+
+	return group.Id != nil && *group.Id == groupId, nil
+}
@@ -0,0 +1,263 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	securityGroupInterfacePublic  = "public"
+	securityGroupInterfacePrivate = "private"
+)
+
+func resourceSoftLayerSecurityGroupAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerSecurityGroupAttachmentCreate,
+		Read:     resourceSoftLayerSecurityGroupAttachmentRead,
+		Delete:   resourceSoftLayerSecurityGroupAttachmentDelete,
+		Exists:   resourceSoftLayerSecurityGroupAttachmentExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"virtual_guest_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"interface": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					networkInterface := v.(string)
+					if networkInterface != securityGroupInterfacePublic && networkInterface != securityGroupInterfacePrivate {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either '%s' or '%s'", k, securityGroupInterfacePublic, securityGroupInterfacePrivate))
+					}
+					return
+				},
+			},
+
+			"network_component_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerSecurityGroupAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId := d.Get("security_group_id").(int)
+	guestId := d.Get("virtual_guest_id").(int)
+	networkInterface := d.Get("interface").(string)
+
+	guest, err := services.GetVirtualGuestService(sess).
+		Id(guestId).
+		Mask("primaryNetworkComponent[id],primaryBackendNetworkComponent[id]").
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving network components of virtual guest %d: %s", guestId, err)
+	}
+
+	var componentId int
+	if networkInterface == securityGroupInterfacePublic {
+		if guest.PrimaryNetworkComponent == nil {
+			return fmt.Errorf("Virtual guest %d doesn't have a public network interface", guestId)
+		}
+		componentId = *guest.PrimaryNetworkComponent.Id
+	} else {
+		if guest.PrimaryBackendNetworkComponent == nil {
+			return fmt.Errorf("Virtual guest %d doesn't have a private network interface", guestId)
+		}
+		componentId = *guest.PrimaryBackendNetworkComponent.Id
+	}
+
+	log.Printf("[INFO] Attaching security group %d to network component %d", groupId, componentId)
+
+	_, err = service.Id(groupId).AttachNetworkComponents([]int{componentId})
+	if err != nil {
+		return fmt.Errorf("Error attaching security group %d to virtual guest %d: %s", groupId, guestId, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%s", groupId, guestId, networkInterface))
+
+	err = waitForSecurityGroupAttachment(sess, groupId, componentId, true)
+	if err != nil {
+		return fmt.Errorf("Error waiting for security group %d to be attached to virtual guest %d: %s", groupId, guestId, err)
+	}
+
+	return resourceSoftLayerSecurityGroupAttachmentRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	groupId, guestId, networkInterface, err := parseSecurityGroupAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	componentId, err := findSecurityGroupAttachment(sess, groupId, guestId, networkInterface)
+	if err != nil {
+		return err
+	}
+	if componentId == 0 {
+		log.Printf("[WARN] Security group %d is not attached to virtual guest %d, removing from state", groupId, guestId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("security_group_id", groupId)
+	d.Set("virtual_guest_id", guestId)
+	d.Set("interface", networkInterface)
+	d.Set("network_component_id", componentId)
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId, guestId, _, err := parseSecurityGroupAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	componentId := d.Get("network_component_id").(int)
+
+	_, err = service.Id(groupId).DetachNetworkComponents([]int{componentId})
+	if err != nil {
+		return fmt.Errorf("Error detaching security group %d from virtual guest %d: %s", groupId, guestId, err)
+	}
+
+	err = waitForSecurityGroupAttachment(sess, groupId, componentId, false)
+	if err != nil {
+		return fmt.Errorf("Error waiting for security group %d to be detached from virtual guest %d: %s", groupId, guestId, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupAttachmentExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	groupId, guestId, networkInterface, err := parseSecurityGroupAttachmentId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	componentId, err := findSecurityGroupAttachment(sess, groupId, guestId, networkInterface)
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return componentId != 0, nil
+}
+
+// Parses an ID of the form <security group ID>:<virtual guest ID>:<interface>.
+func parseSecurityGroupAttachmentId(id string) (int, int, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return -1, -1, "", fmt.Errorf(
+			"Not a valid security group attachment ID, must be <security group ID>:<virtual guest ID>:<interface>: %s", id)
+	}
+
+	groupId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return -1, -1, "", fmt.Errorf("Not a valid security group ID, must be an integer: %s", err)
+	}
+
+	guestId, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return -1, -1, "", fmt.Errorf("Not a valid virtual guest ID, must be an integer: %s", err)
+	}
+
+	return groupId, guestId, parts[2], nil
+}
+
+// Returns the ID of the network component of the virtual guest to which the security group is attached,
+// or 0 if the security group is not attached to the interface.
+func findSecurityGroupAttachment(sess *session.Session, groupId int, guestId int, networkInterface string) (int, error) {
+	guest, err := services.GetVirtualGuestService(sess).
+		Id(guestId).
+		Mask("primaryNetworkComponent[id,securityGroupBindings[securityGroupId]]," +
+			"primaryBackendNetworkComponent[id,securityGroupBindings[securityGroupId]]").
+		GetObject()
+	if err != nil {
+		return 0, err
+	}
+
+	component := guest.PrimaryBackendNetworkComponent
+	if networkInterface == securityGroupInterfacePublic {
+		component = guest.PrimaryNetworkComponent
+	}
+	if component == nil {
+		return 0, nil
+	}
+
+	for _, binding := range component.SecurityGroupBindings {
+		if binding.SecurityGroupId != nil && *binding.SecurityGroupId == groupId {
+			return *component.Id, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// Waits until the network component is attached to, or detached from, the security group.
+func waitForSecurityGroupAttachment(sess *session.Session, groupId int, componentId int, attached bool) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			bindings, err := services.GetNetworkSecurityGroupService(sess).
+				Id(groupId).
+				Mask("networkComponentId").
+				GetNetworkComponentBindings()
+			if err != nil {
+				return nil, "", err
+			}
+
+			found := false
+			for _, binding := range bindings {
+				if binding.NetworkComponentId != nil && *binding.NetworkComponentId == componentId {
+					found = true
+				}
+			}
+
+			if found == attached {
+				return bindings, "complete", nil
+			}
+			return bindings, "pending", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
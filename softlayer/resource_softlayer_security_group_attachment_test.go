@@ -0,0 +1,104 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSoftLayerSecurityGroupAttachment_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerSecurityGroupAttachmentConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSecurityGroupAttachmentExists("softlayer_security_group_attachment.public"),
+					testAccCheckSoftLayerSecurityGroupAttachmentExists("softlayer_security_group_attachment.private"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_security_group_attachment.public", "network_component_id"),
+				),
+			},
+
+			{
+				ResourceName:      "softlayer_security_group_attachment.public",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerSecurityGroupAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		groupId, guestId, networkInterface, err := parseSecurityGroupAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		componentId, err := findSecurityGroupAttachment(
+			testAccProvider.Meta().(ProviderConfig).SoftLayerSession(), groupId, guestId, networkInterface)
+		if err != nil {
+			return err
+		}
+
+		if componentId == 0 {
+			return fmt.Errorf("Security group %d is not attached to virtual guest %d", groupId, guestId)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerSecurityGroupAttachmentConfig_basic = `
+resource "softlayer_virtual_guest" "sgvm1" {
+    hostname = "sgvm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_security_group" "web" {
+    name = "web"
+}
+
+resource "softlayer_security_group_rule" "https" {
+    security_group_id = "${softlayer_security_group.web.id}"
+    direction = "ingress"
+    protocol = "tcp"
+    port_range_min = 443
+    port_range_max = 443
+}
+
+resource "softlayer_security_group_attachment" "public" {
+    security_group_id = "${softlayer_security_group.web.id}"
+    virtual_guest_id = "${softlayer_virtual_guest.sgvm1.id}"
+    interface = "public"
+}
+
+resource "softlayer_security_group_attachment" "private" {
+    security_group_id = "${softlayer_security_group.web.id}"
+    virtual_guest_id = "${softlayer_virtual_guest.sgvm1.id}"
+    interface = "private"
+}
+`
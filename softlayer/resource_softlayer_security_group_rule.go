@@ -0,0 +1,366 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const securityGroupRuleMask = "id,direction,ethertype,portRangeMin,portRangeMax,protocol,remoteIp,remoteGroupId"
+
+func resourceSoftLayerSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerSecurityGroupRuleCreate,
+		Read:     resourceSoftLayerSecurityGroupRuleRead,
+		Delete:   resourceSoftLayerSecurityGroupRuleDelete,
+		Exists:   resourceSoftLayerSecurityGroupRuleExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"direction": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					direction := v.(string)
+					if direction != "ingress" && direction != "egress" {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either 'ingress' or 'egress'", k))
+					}
+					return
+				},
+			},
+
+			"ether_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "IPv4",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					etherType := v.(string)
+					if etherType != "IPv4" && etherType != "IPv6" {
+						errors = append(errors, fmt.Errorf(
+							"%s should be either 'IPv4' or 'IPv6'", k))
+					}
+					return
+				},
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					protocol := v.(string)
+					if protocol != "icmp" && protocol != "tcp" && protocol != "udp" {
+						errors = append(errors, fmt.Errorf(
+							"%s should be one of 'icmp', 'tcp' or 'udp'", k))
+					}
+					return
+				},
+			},
+
+			"port_range_min": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					port := v.(int)
+					if port < 0 || port > 65535 {
+						errors = append(errors, fmt.Errorf(
+							"%s should be between 0 and 65535", k))
+					}
+					return
+				},
+			},
+
+			"port_range_max": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					port := v.(int)
+					if port < 0 || port > 65535 {
+						errors = append(errors, fmt.Errorf(
+							"%s should be between 0 and 65535", k))
+					}
+					return
+				},
+			},
+
+			"remote_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"remote_group_id"},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					address := v.(string)
+					if net.ParseIP(address) == nil {
+						if _, _, err := net.ParseCIDR(address); err != nil {
+							errors = append(errors, fmt.Errorf(
+								"%s should be an IP address or a CIDR: %s", k, address))
+						}
+					}
+					return
+				},
+			},
+
+			"remote_group_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"remote_ip"},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerSecurityGroupRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId := d.Get("security_group_id").(int)
+
+	template := datatypes.Network_SecurityGroup_Rule{
+		Direction: sl.String(d.Get("direction").(string)),
+		Ethertype: sl.String(d.Get("ether_type").(string)),
+	}
+	if protocol, ok := d.GetOk("protocol"); ok {
+		template.Protocol = sl.String(protocol.(string))
+	}
+	if portRangeMin, ok := d.GetOk("port_range_min"); ok {
+		template.PortRangeMin = sl.Int(portRangeMin.(int))
+	}
+	if portRangeMax, ok := d.GetOk("port_range_max"); ok {
+		template.PortRangeMax = sl.Int(portRangeMax.(int))
+	}
+	if remoteIp, ok := d.GetOk("remote_ip"); ok {
+		template.RemoteIp = sl.String(remoteIp.(string))
+	}
+	if remoteGroupId, ok := d.GetOk("remote_group_id"); ok {
+		template.RemoteGroupId = sl.Int(remoteGroupId.(int))
+	}
+
+	// addRules doesn't return the IDs of the added rules. The new rule is found by comparing the rules
+	// of the security group before and after the request.
+	rules, err := service.Id(groupId).Mask("id").GetRules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving rules of security group %d: %s", groupId, err)
+	}
+	existingRuleIds := make(map[int]bool, len(rules))
+	for _, rule := range rules {
+		existingRuleIds[*rule.Id] = true
+	}
+
+	log.Printf("[INFO] Adding a rule to security group %d", groupId)
+
+	_, err = service.Id(groupId).AddRules([]datatypes.Network_SecurityGroup_Rule{template})
+	if err != nil {
+		return fmt.Errorf("Error adding a rule to security group %d: %s", groupId, err)
+	}
+
+	rule, err := waitForSecurityGroupRule(sess, groupId, func(rule datatypes.Network_SecurityGroup_Rule) bool {
+		return !existingRuleIds[*rule.Id] && securityGroupRuleMatches(rule, template)
+	})
+	if err != nil {
+		return fmt.Errorf("Error waiting for the rule of security group %d to be added: %s", groupId, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", groupId, *rule.Id))
+
+	return resourceSoftLayerSecurityGroupRuleRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupRuleRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	groupId, ruleId, err := parseSecurityGroupRuleId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	rule, err := getSecurityGroupRule(sess, groupId, ruleId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving rules of security group %d: %s", groupId, err)
+	}
+	if rule == nil {
+		log.Printf("[WARN] Rule %d of security group %d not found, removing from state", ruleId, groupId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("security_group_id", groupId)
+	d.Set("direction", sl.Get(rule.Direction, nil))
+	d.Set("ether_type", sl.Get(rule.Ethertype, nil))
+	d.Set("protocol", sl.Get(rule.Protocol, nil))
+	d.Set("port_range_min", sl.Get(rule.PortRangeMin, nil))
+	d.Set("port_range_max", sl.Get(rule.PortRangeMax, nil))
+	d.Set("remote_ip", sl.Get(rule.RemoteIp, nil))
+	d.Set("remote_group_id", sl.Get(rule.RemoteGroupId, nil))
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	groupId, ruleId, err := parseSecurityGroupRuleId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	_, err = service.Id(groupId).RemoveRules([]int{ruleId})
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error removing rule %d of security group %d: %s", ruleId, groupId, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			rule, err := getSecurityGroupRule(sess, groupId, ruleId)
+			if err != nil {
+				return nil, "", fmt.Errorf("Error retrieving rules of security group %d: %s", groupId, err)
+			}
+			if rule != nil {
+				return rule, "pending", nil
+			}
+			return true, "complete", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for rule %d of security group %d to be removed: %s", ruleId, groupId, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	groupId, ruleId, err := parseSecurityGroupRuleId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	rule, err := getSecurityGroupRule(sess, groupId, ruleId)
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving rules of security group %d: %s", groupId, err)
+	}
+
+	return rule != nil, nil
+}
+
+// Parses an ID of the form <security group ID>:<rule ID>.
+func parseSecurityGroupRuleId(id string) (int, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return -1, -1, fmt.Errorf("Not a valid security group rule ID, must be <security group ID>:<rule ID>: %s", id)
+	}
+
+	groupId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return -1, -1, fmt.Errorf("Not a valid security group ID, must be an integer: %s", err)
+	}
+
+	ruleId, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return -1, -1, fmt.Errorf("Not a valid rule ID, must be an integer: %s", err)
+	}
+
+	return groupId, ruleId, nil
+}
+
+// Returns the rule of the security group, or nil if the security group doesn't have the rule.
+// API errors are returned as they are, so that callers can check for a missing security group.
+func getSecurityGroupRule(sess *session.Session, groupId int, ruleId int) (*datatypes.Network_SecurityGroup_Rule, error) {
+	rules, err := services.GetNetworkSecurityGroupService(sess).
+		Id(groupId).
+		Mask(securityGroupRuleMask).
+		GetRules()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if rule.Id != nil && *rule.Id == ruleId {
+			return &rule, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Waits until the security group has a rule accepted by the match function, and returns the rule.
+func waitForSecurityGroupRule(sess *session.Session, groupId int, match func(datatypes.Network_SecurityGroup_Rule) bool) (
+	datatypes.Network_SecurityGroup_Rule, error) {
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			rules, err := services.GetNetworkSecurityGroupService(sess).
+				Id(groupId).
+				Mask(securityGroupRuleMask).
+				GetRules()
+			if err != nil {
+				return nil, "", err
+			}
+
+			for _, rule := range rules {
+				if match(rule) {
+					return rule, "complete", nil
+				}
+			}
+			return datatypes.Network_SecurityGroup_Rule{}, "pending", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	result, err := stateConf.WaitForState()
+	if err != nil {
+		return datatypes.Network_SecurityGroup_Rule{}, err
+	}
+
+	return result.(datatypes.Network_SecurityGroup_Rule), nil
+}
+
+func securityGroupRuleMatches(rule datatypes.Network_SecurityGroup_Rule, template datatypes.Network_SecurityGroup_Rule) bool {
+	return sl.Get(rule.Direction, "") == sl.Get(template.Direction, "") &&
+		sl.Get(rule.Ethertype, "") == sl.Get(template.Ethertype, "") &&
+		sl.Get(rule.Protocol, "") == sl.Get(template.Protocol, "") &&
+		sl.Get(rule.PortRangeMin, 0) == sl.Get(template.PortRangeMin, 0) &&
+		sl.Get(rule.PortRangeMax, 0) == sl.Get(template.PortRangeMax, 0) &&
+		sl.Get(rule.RemoteIp, "") == sl.Get(template.RemoteIp, "") &&
+		sl.Get(rule.RemoteGroupId, 0) == sl.Get(template.RemoteGroupId, 0)
+}
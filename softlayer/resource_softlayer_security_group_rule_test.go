@@ -0,0 +1,142 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSoftLayerSecurityGroupRule_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerSecurityGroupRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerSecurityGroupRuleConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSecurityGroupRuleExists("softlayer_security_group_rule.https"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.https", "direction", "ingress"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.https", "ether_type", "IPv4"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.https", "protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.https", "port_range_min", "443"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.https", "port_range_max", "443"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.https", "remote_ip", "10.0.0.0/8"),
+
+					testAccCheckSoftLayerSecurityGroupRuleExists("softlayer_security_group_rule.ssh_v6"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.ssh_v6", "ether_type", "IPv6"),
+
+					testAccCheckSoftLayerSecurityGroupRuleExists("softlayer_security_group_rule.from_web"),
+					testAccCheckSoftLayerResources("softlayer_security_group_rule.from_web", "remote_group_id",
+						"softlayer_security_group.web", "id"),
+				),
+			},
+
+			{
+				ResourceName:      "softlayer_security_group_rule.https",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerSecurityGroupRuleDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(ProviderConfig).SoftLayerSession()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_security_group_rule" {
+			continue
+		}
+
+		groupId, ruleId, err := parseSecurityGroupRuleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		// The security group is destroyed with the rule.
+		rule, _ := getSecurityGroupRule(sess, groupId, ruleId)
+		if rule != nil {
+			return fmt.Errorf("Rule %d of security group %d still exists", ruleId, groupId)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerSecurityGroupRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		groupId, ruleId, err := parseSecurityGroupRuleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rule, err := getSecurityGroupRule(testAccProvider.Meta().(ProviderConfig).SoftLayerSession(), groupId, ruleId)
+		if err != nil {
+			return err
+		}
+
+		if rule == nil {
+			return fmt.Errorf("Rule %d of security group %d not found", ruleId, groupId)
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerSecurityGroupRuleConfig_basic = `
+resource "softlayer_security_group" "web" {
+    name = "web"
+}
+
+resource "softlayer_security_group" "db" {
+    name = "db"
+}
+
+resource "softlayer_security_group_rule" "https" {
+    security_group_id = "${softlayer_security_group.web.id}"
+    direction = "ingress"
+    ether_type = "IPv4"
+    protocol = "tcp"
+    port_range_min = 443
+    port_range_max = 443
+    remote_ip = "10.0.0.0/8"
+}
+
+resource "softlayer_security_group_rule" "ssh_v6" {
+    security_group_id = "${softlayer_security_group.web.id}"
+    direction = "ingress"
+    ether_type = "IPv6"
+    protocol = "tcp"
+    port_range_min = 22
+    port_range_max = 22
+}
+
+resource "softlayer_security_group_rule" "from_web" {
+    security_group_id = "${softlayer_security_group.db.id}"
+    direction = "ingress"
+    protocol = "tcp"
+    port_range_min = 3306
+    port_range_max = 3306
+    remote_group_id = "${softlayer_security_group.web.id}"
+}
+`
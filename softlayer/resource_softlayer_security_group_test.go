@@ -0,0 +1,109 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerSecurityGroup_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerSecurityGroupConfig_basic, "web", "web servers"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSecurityGroupExists("softlayer_security_group.web"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.web", "name", "web"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.web", "description", "web servers"),
+				),
+			},
+
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerSecurityGroupConfig_basic, "web_updated", "public web servers"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSecurityGroupExists("softlayer_security_group.web"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.web", "name", "web_updated"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.web", "description", "public web servers"),
+				),
+			},
+
+			{
+				ResourceName:      "softlayer_security_group.web",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerSecurityGroupDestroy(s *terraform.State) error {
+	service := services.GetNetworkSecurityGroupService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_security_group" {
+			continue
+		}
+
+		groupId, _ := strconv.Atoi(rs.Primary.ID)
+
+		_, err := service.Id(groupId).GetObject()
+		if err == nil {
+			return fmt.Errorf("Security group %d still exists", groupId)
+		}
+		if apiErr, ok := err.(sl.Error); !ok || apiErr.StatusCode != 404 {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerSecurityGroupExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		groupId, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		service := services.GetNetworkSecurityGroupService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+		group, err := service.Id(groupId).GetObject()
+		if err != nil {
+			return err
+		}
+
+		if *group.Id != groupId {
+			return fmt.Errorf("Security group not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerSecurityGroupConfig_basic = `
+resource "softlayer_security_group" "web" {
+    name = "%s"
+    description = "%s"
+}
+`
@@ -0,0 +1,381 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	storageAccessMask = "id,allowedIpAddresses[id,ipAddress],allowedSubnets[id,networkIdentifier,cidr]," +
+		"allowedVirtualGuests[id,allowedHost[name,credential[username,password]]]," +
+		"allowedHardware[id,allowedHost[name,credential[username,password]]]"
+
+	storageAccessVirtualGuest = "SoftLayer_Virtual_Guest"
+	storageAccessHardware     = "SoftLayer_Hardware"
+	storageAccessSubnet       = "SoftLayer_Network_Subnet"
+	storageAccessIpAddress    = "SoftLayer_Network_Subnet_IpAddress"
+)
+
+func resourceSoftLayerStorageAccess() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerStorageAccessCreate,
+		Read:     resourceSoftLayerStorageAccessRead,
+		Delete:   resourceSoftLayerStorageAccessDelete,
+		Exists:   resourceSoftLayerStorageAccessExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"virtual_guest_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"hardware_id", "subnet", "ip_address"},
+			},
+
+			"hardware_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id", "subnet", "ip_address"},
+			},
+
+			"subnet": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id", "hardware_id", "ip_address"},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					subnet := strings.Split(v.(string), "/")
+					if len(subnet) != 2 {
+						errors = append(errors, fmt.Errorf(
+							"%s should be a subnet in CIDR notation, for example 10.1.1.0/26", k))
+						return
+					}
+					if _, err := strconv.Atoi(subnet[1]); err != nil {
+						errors = append(errors, fmt.Errorf(
+							"%s should be a subnet in CIDR notation, for example 10.1.1.0/26", k))
+					}
+					return
+				},
+			},
+
+			"ip_address": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id", "hardware_id", "subnet"},
+			},
+
+			"host_iqn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageAccessCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	volumeId := d.Get("volume_id").(int)
+
+	objectType, hostId, err := getStorageAccessHost(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating storage access: %s", err)
+	}
+
+	log.Printf("[INFO] Authorizing %s (%d) to access storage (%d)", objectType, hostId, volumeId)
+
+	err = modifyStorageAccess(sess, volumeId, objectType, hostId, true)
+	if err != nil {
+		return fmt.Errorf("Error creating storage access: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s:%d", volumeId, objectType, hostId))
+
+	_, err = waitForStorageAccess(sess, volumeId, objectType, hostId)
+	if err != nil {
+		return fmt.Errorf("Error waiting for storage access (%s) to be authorized: %s", d.Id(), err)
+	}
+
+	return resourceSoftLayerStorageAccessRead(d, meta)
+}
+
+func resourceSoftLayerStorageAccessRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	volumeId, objectType, hostId, err := parseStorageAccessId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	storage, err := services.GetNetworkStorageService(sess).
+		Id(volumeId).
+		Mask(storageAccessMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage access: %s", err)
+	}
+
+	attributes, ok := findStorageAccess(storage, objectType, hostId)
+	if !ok {
+		log.Printf("[WARN] Storage access (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("volume_id", volumeId)
+	for k, v := range attributes {
+		d.Set(k, v)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageAccessDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	volumeId, objectType, hostId, err := parseStorageAccessId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = modifyStorageAccess(sess, volumeId, objectType, hostId, false)
+	if err != nil {
+		return fmt.Errorf("Error deleting storage access: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageAccessExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	volumeId, objectType, hostId, err := parseStorageAccessId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	storage, err := services.GetNetworkStorageService(sess).
+		Id(volumeId).
+		Mask(storageAccessMask).
+		GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving storage access: %s", err)
+	}
+
+	_, ok := findStorageAccess(storage, objectType, hostId)
+	return ok, nil
+}
+
+// Parses an ID of the form <volume id>:<object type>:<host id>.
+func parseStorageAccessId(id string) (int, string, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return 0, "", 0, fmt.Errorf("Not a valid storage access ID, must be <volume id>:<object type>:<host id>: %s", id)
+	}
+
+	volumeId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	hostId, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	return volumeId, parts[1], hostId, nil
+}
+
+// Returns the object type and the ID of the host to authorize. Subnets and IP addresses are looked up in the account.
+func getStorageAccessHost(d *schema.ResourceData, sess *session.Session) (string, int, error) {
+	if id, ok := d.GetOk("virtual_guest_id"); ok {
+		return storageAccessVirtualGuest, id.(int), nil
+	}
+
+	if id, ok := d.GetOk("hardware_id"); ok {
+		return storageAccessHardware, id.(int), nil
+	}
+
+	if subnet, ok := d.GetOk("subnet"); ok {
+		subnetArr := strings.Split(subnet.(string), "/")
+		cidr, err := strconv.Atoi(subnetArr[1])
+		if err != nil {
+			return "", 0, err
+		}
+		filterStr := fmt.Sprintf("{\"subnets\":{\"networkIdentifier\":{\"operation\":\"%s\"},\"cidr\":{\"operation\":\"%d\"}}}", subnetArr[0], cidr)
+		subnetObject, err := services.GetAccountService(sess).
+			Filter(filterStr).GetSubnets()
+		if err != nil {
+			return "", 0, err
+		}
+		if len(subnetObject) != 1 {
+			return "", 0, fmt.Errorf("Number of subnet is %d", len(subnetObject))
+		}
+		return storageAccessSubnet, *subnetObject[0].Id, nil
+	}
+
+	if ip, ok := d.GetOk("ip_address"); ok {
+		ipObject, err := services.GetAccountService(sess).
+			Filter(filter.Build(
+				filter.Path("ipAddresses.ipAddress").
+					Eq(ip.(string)))).GetIpAddresses()
+		if err != nil {
+			return "", 0, err
+		}
+		if len(ipObject) != 1 {
+			return "", 0, fmt.Errorf("Number of IP address is %d", len(ipObject))
+		}
+		return storageAccessIpAddress, *ipObject[0].Id, nil
+	}
+
+	return "", 0, fmt.Errorf("One of virtual_guest_id, hardware_id, subnet or ip_address must be set")
+}
+
+// Allows or removes access to the storage from a host. Concurrent access control modifications of the same
+// storage are rejected by SoftLayer, so the request is retried until it is accepted.
+func modifyStorageAccess(sess *session.Session, volumeId int, objectType string, hostId int, allow bool) error {
+	service := services.GetNetworkStorageService(sess).Id(volumeId)
+	hosts := []datatypes.Container_Network_Storage_Host{
+		{
+			Id:         sl.Int(hostId),
+			ObjectType: sl.String(objectType),
+		},
+	}
+
+	for {
+		var err error
+		if allow {
+			_, err = service.AllowAccessFromHostList(hosts)
+		} else {
+			_, err = service.RemoveAccessFromHostList(hosts)
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "SoftLayer_Exception_Network_Storage_Group_MassAccessControlModification") {
+				time.Sleep(retryTime * time.Second)
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// Finds the host in the access control list of the storage and returns its resource attributes.
+func findStorageAccess(storage datatypes.Network_Storage, objectType string, hostId int) (map[string]interface{}, bool) {
+	switch objectType {
+	case storageAccessVirtualGuest:
+		for _, guest := range storage.AllowedVirtualGuests {
+			if guest.Id != nil && *guest.Id == hostId {
+				attributes := flattenStorageAllowedHost(guest.AllowedHost)
+				attributes["virtual_guest_id"] = hostId
+				return attributes, true
+			}
+		}
+	case storageAccessHardware:
+		for _, hardware := range storage.AllowedHardware {
+			if hardware.Id != nil && *hardware.Id == hostId {
+				attributes := flattenStorageAllowedHost(hardware.AllowedHost)
+				attributes["hardware_id"] = hostId
+				return attributes, true
+			}
+		}
+	case storageAccessSubnet:
+		for _, subnet := range storage.AllowedSubnets {
+			if subnet.Id != nil && *subnet.Id == hostId {
+				return map[string]interface{}{
+					"subnet": *subnet.NetworkIdentifier + "/" + strconv.Itoa(*subnet.Cidr),
+				}, true
+			}
+		}
+	case storageAccessIpAddress:
+		for _, ip := range storage.AllowedIpAddresses {
+			if ip.Id != nil && *ip.Id == hostId {
+				return map[string]interface{}{
+					"ip_address": *ip.IpAddress,
+				}, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func flattenStorageAllowedHost(host *datatypes.Network_Storage_Allowed_Host) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	if host == nil {
+		return attributes
+	}
+
+	attributes["host_iqn"] = sl.Get(host.Name, "")
+	if host.Credential != nil {
+		attributes["username"] = sl.Get(host.Credential.Username, "")
+		attributes["password"] = sl.Get(host.Credential.Password, "")
+	}
+
+	return attributes
+}
+
+// Waits until the host appears in the access control list of the storage.
+func waitForStorageAccess(sess *session.Session, volumeId int, objectType string, hostId int) (interface{}, error) {
+	log.Printf("Waiting for %s (%d) to be authorized to access storage (%d).", objectType, hostId, volumeId)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "pending"},
+		Target:  []string{"authorized"},
+		Refresh: func() (interface{}, string, error) {
+			storage, err := services.GetNetworkStorageService(sess).
+				Id(volumeId).
+				Mask(storageAccessMask).
+				GetObject()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Error retrieving storage: %s", err)
+				}
+				return false, "retry", nil
+			}
+
+			if _, ok := findStorageAccess(storage, objectType, hostId); ok {
+				return storage, "authorized", nil
+			}
+
+			return storage, "pending", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
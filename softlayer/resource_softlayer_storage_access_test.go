@@ -0,0 +1,133 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccSoftLayerStorageAccess_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerStorageAccessDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerStorageAccessConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerStorageAccessExists("softlayer_storage_access.access"),
+					testAccCheckSoftLayerResources("softlayer_storage_access.access", "volume_id",
+						"softlayer_block_storage.bs_endurance", "id"),
+					testAccCheckSoftLayerResources("softlayer_storage_access.access", "virtual_guest_id",
+						"softlayer_virtual_guest.storagevm3", "id"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_access.access", "host_iqn"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_access.access", "username"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "access_mode", "additive"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "allowed_virtual_guest_ids.#", "0"),
+				),
+			},
+
+			{
+				ResourceName:      "softlayer_storage_access.access",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageAccessDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_storage_access" {
+			continue
+		}
+
+		volumeId, objectType, hostId, err := parseStorageAccessId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		storage, err := service.Id(volumeId).Mask(storageAccessMask).GetObject()
+		if err != nil {
+			// The storage volume is destroyed as well
+			continue
+		}
+
+		if _, ok := findStorageAccess(storage, objectType, hostId); ok {
+			return fmt.Errorf("Storage access %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerStorageAccessExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		volumeId, objectType, hostId, err := parseStorageAccessId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+		storage, err := service.Id(volumeId).Mask(storageAccessMask).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if _, ok := findStorageAccess(storage, objectType, hostId); !ok {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerStorageAccessConfig_basic = `
+resource "softlayer_virtual_guest" "storagevm3" {
+    hostname = "storagevm3"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_block_storage" "bs_endurance" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 0.25
+        os_format_type = "Linux"
+        access_mode = "additive"
+}
+
+resource "softlayer_storage_access" "access" {
+        volume_id = "${softlayer_block_storage.bs_endurance.id}"
+        virtual_guest_id = "${softlayer_virtual_guest.storagevm3.id}"
+}
+`
@@ -0,0 +1,307 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	storageOriginMask = "id,capacityGb,iops,storageType[keyName,description],snapshotCapacityGb,properties[type]," +
+		"osType[id,keyName],schedules[id,active,type[keyname]]"
+	storageReplicaMask = "id,username,serviceResourceName,serviceResourceBackendIpAddress,replicationStatus,replicationPartners[id]"
+)
+
+func resourceSoftLayerStorageReplica() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerStorageReplicaCreate,
+		Read:     resourceSoftLayerStorageReplicaRead,
+		Update:   resourceSoftLayerStorageReplicaUpdate,
+		Delete:   resourceSoftLayerStorageReplicaDelete,
+		Exists:   resourceSoftLayerStorageReplicaExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"origin_volume_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schedule_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					scheduleType := v.(string)
+					if _, ok := snapshotScheduleTypeMap[scheduleType]; !ok {
+						errors = append(errors, fmt.Errorf(
+							"%s should be one of 'HOURLY', 'DAILY' or 'WEEKLY'", k))
+					}
+					return
+				},
+			},
+
+			"failover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"volumename": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"target_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"replication_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageReplicaCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	originVolumeId := d.Get("origin_volume_id").(int)
+
+	origin, err := services.GetNetworkStorageService(sess).
+		Id(originVolumeId).
+		Mask(storageOriginMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving origin storage (%d): %s", originVolumeId, err)
+	}
+
+	order, err := buildStorageReplicantOrderContainer(sess, origin, d.Get("datacenter").(string), d.Get("schedule_type").(string))
+	if err != nil {
+		return fmt.Errorf("Error while creating storage replica: %s", err)
+	}
+
+	log.Println("[INFO] Creating storage replica")
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+
+	// Find the replicant volume
+	replica, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", *replica.Id))
+
+	// Wait for replicant availability
+	_, err = WaitForStorageAvailable(d, meta)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for storage replica (%s) to become ready: %s", d.Id(), err)
+	}
+
+	// SoftLayer changes the device ID after completion of provisioning. It is necessary to refresh device ID.
+	replica, err = findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", *replica.Id))
+
+	log.Printf("[INFO] Storage replica ID: %s", d.Id())
+
+	if d.Get("failover").(bool) {
+		err = failoverStorageReplica(sess, originVolumeId, *replica.Id, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerStorageReplicaRead(d, meta)
+}
+
+func resourceSoftLayerStorageReplicaRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	replica, err := services.GetNetworkStorageService(sess).
+		Id(id).
+		Mask(storageReplicaMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage replica: %s", err)
+	}
+
+	// The replication partner of a replicant is its origin volume.
+	if len(replica.ReplicationPartners) > 0 && replica.ReplicationPartners[0].Id != nil {
+		d.Set("origin_volume_id", *replica.ReplicationPartners[0].Id)
+	}
+
+	d.Set("volumename", sl.Get(replica.Username, nil))
+	d.Set("target_address", sl.Get(replica.ServiceResourceBackendIpAddress, nil))
+	d.Set("replication_status", sl.Get(replica.ReplicationStatus, nil))
+
+	if replica.ServiceResourceName != nil {
+		r, _ := regexp.Compile("[a-zA-Z]{3}[0-9]{2}")
+		d.Set("datacenter", r.FindString(*replica.ServiceResourceName))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageReplicaUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("failover") {
+		err = failoverStorageReplica(sess, d.Get("origin_volume_id").(int), id, d.Get("failover").(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerStorageReplicaRead(d, meta)
+}
+
+func resourceSoftLayerStorageReplicaDelete(d *schema.ResourceData, meta interface{}) error {
+	return resourceSoftLayerFileStorageDelete(d, meta)
+}
+
+func resourceSoftLayerStorageReplicaExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	return resourceSoftLayerFileStorageExists(d, meta)
+}
+
+// Builds an order for a replicant of the origin volume. The replicant has the same type, capacity, IOPS and
+// snapshot capacity as the origin volume and replicates using the origin snapshot schedule of scheduleType.
+func buildStorageReplicantOrderContainer(
+	sess *session.Session,
+	origin datatypes.Network_Storage,
+	datacenter string,
+	scheduleType string) (datatypes.Container_Product_Order_Network_Storage_Enterprise, error) {
+
+	if origin.StorageType == nil || origin.StorageType.Description == nil || origin.StorageType.KeyName == nil {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{},
+			fmt.Errorf("Failed to retrieve the storage type of the origin storage.")
+	}
+
+	storageType := strings.Fields(*origin.StorageType.Description)[0]
+	if storageType != enduranceType {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{},
+			fmt.Errorf("Replication is only supported for %s storage.", enduranceType)
+	}
+
+	storageProtocol := fileStorage
+	if strings.Contains(*origin.StorageType.KeyName, "BLOCK") {
+		storageProtocol = blockStorage
+	}
+
+	iops, err := getIops(origin, storageType)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{}, err
+	}
+
+	snapshotCapacity := 0
+	if origin.SnapshotCapacityGb != nil {
+		snapshotCapacity, _ = strconv.Atoi(*origin.SnapshotCapacityGb)
+	}
+	if snapshotCapacity == 0 {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{},
+			fmt.Errorf("The origin storage must have snapshot capacity to be replicated.")
+	}
+
+	// Replication uses the snapshot schedule of the origin volume.
+	var scheduleId *int
+	for _, schedule := range origin.Schedules {
+		if schedule.Type != nil && schedule.Type.Keyname != nil &&
+			*schedule.Type.Keyname == snapshotScheduleTypeMap[scheduleType] &&
+			schedule.Active != nil && *schedule.Active == 1 {
+			scheduleId = schedule.Id
+		}
+	}
+	if scheduleId == nil {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{},
+			fmt.Errorf("The origin storage has no %s snapshot schedule.", scheduleType)
+	}
+
+	order, err := buildStorageProductOrderContainer(sess, storageType, iops, *origin.CapacityGb, snapshotCapacity, storageProtocol, datacenter)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{}, err
+	}
+
+	// Add replication price
+	productItems, err := product.GetPackageProducts(sess, *order.PackageId, itemMask)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{}, err
+	}
+	replicationPrice, err := getPrice(productItems, "REPLICATION_FOR_TIERBASED_PERFORMANCE", "performance_storage_replication",
+		"STORAGE_TIER_LEVEL", enduranceCapacityRestrictionMap[iops])
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_Enterprise{}, err
+	}
+	order.Prices = append(order.Prices, replicationPrice)
+
+	replicantOrder := datatypes.Container_Product_Order_Network_Storage_Enterprise{
+		Container_Product_Order: order,
+		OriginVolumeId:          origin.Id,
+		OriginVolumeScheduleId:  scheduleId,
+	}
+
+	if storageProtocol == blockStorage && origin.OsType != nil {
+		replicantOrder.OsFormatType = &datatypes.Network_Storage_Iscsi_OS_Type{
+			Id:      origin.OsType.Id,
+			KeyName: origin.OsType.KeyName,
+		}
+	}
+
+	return replicantOrder, nil
+}
+
+// Fails over the origin volume to the replicant, or fails back from the replicant when failover is false.
+func failoverStorageReplica(sess *session.Session, originVolumeId int, replicaId int, failover bool) error {
+	service := services.GetNetworkStorageService(sess).Id(originVolumeId)
+
+	var err error
+	if failover {
+		log.Printf("[INFO] Failing over storage (%d) to replica (%d)", originVolumeId, replicaId)
+		_, err = service.FailoverToReplicant(sl.Int(replicaId))
+	} else {
+		log.Printf("[INFO] Failing back storage (%d) from replica (%d)", originVolumeId, replicaId)
+		_, err = service.FailbackFromReplicant()
+	}
+	if err != nil {
+		return fmt.Errorf("Error during failover of storage (%d): %s", originVolumeId, err)
+	}
+
+	_, err = waitForStorageTransactions(sess, originVolumeId)
+	if err != nil {
+		return fmt.Errorf("Error waiting for failover of storage (%d): %s", originVolumeId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccSoftLayerStorageReplica_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerStorageReplicaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerStorageReplicaConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerFileStorageExists("softlayer_storage_replica.replica"),
+					testAccCheckSoftLayerResources("softlayer_storage_replica.replica", "origin_volume_id",
+						"softlayer_block_storage.origin", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "datacenter", "dal09"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_replica.replica", "target_address"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_replica.replica", "volumename"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageReplicaDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_storage_replica" {
+			continue
+		}
+
+		replicaId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Cancelled replicas keep their billing item until the cancellation is processed.
+		billingItem, err := service.Id(replicaId).GetBillingItem()
+		if err == nil && billingItem.Id != nil && billingItem.CancellationDate == nil {
+			return fmt.Errorf("Storage replica %d still exists", replicaId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerStorageReplicaConfig_basic = `
+resource "softlayer_block_storage" "origin" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 2
+        snapshot_capacity = 10
+        os_format_type = "Linux"
+        snapshot_schedule {
+            schedule_type = "DAILY"
+            retention_count = 3
+            hour = 1
+        }
+}
+
+resource "softlayer_storage_replica" "replica" {
+        origin_volume_id = "${softlayer_block_storage.origin.id}"
+        datacenter = "dal09"
+        schedule_type = "DAILY"
+}
+`
@@ -0,0 +1,274 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const storageSnapshotMask = "id,username,notes,snapshotCreationTimestamp,snapshotSizeBytes,parentVolume[id]"
+
+func resourceSoftLayerStorageSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerStorageSnapshotCreate,
+		Read:     resourceSoftLayerStorageSnapshotRead,
+		Update:   resourceSoftLayerStorageSnapshotUpdate,
+		Delete:   resourceSoftLayerStorageSnapshotDelete,
+		Exists:   resourceSoftLayerStorageSnapshotExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"restore_to_volume": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size_bytes": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	volumeId := d.Get("volume_id").(int)
+
+	// Snapshots can't be taken while the volume is being provisioned or restored.
+	_, err := waitForStorageTransactions(sess, volumeId)
+	if err != nil {
+		return fmt.Errorf("Error waiting for storage (%d) to become ready: %s", volumeId, err)
+	}
+
+	snapshot, err := services.GetNetworkStorageService(sess).
+		Id(volumeId).
+		CreateSnapshot(sl.String(d.Get("notes").(string)))
+	if err != nil {
+		return fmt.Errorf("Error creating snapshot of storage (%d): %s", volumeId, err)
+	}
+
+	d.SetId(strconv.Itoa(*snapshot.Id))
+	log.Printf("[INFO] Storage snapshot ID: %s", d.Id())
+
+	if d.Get("restore_to_volume").(bool) {
+		err = restoreStorageSnapshot(sess, volumeId, *snapshot.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerStorageSnapshotRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	snapshot, err := services.GetNetworkStorageService(sess).
+		Id(id).
+		Mask(storageSnapshotMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage snapshot: %s", err)
+	}
+
+	if snapshot.ParentVolume != nil && snapshot.ParentVolume.Id != nil {
+		d.Set("volume_id", *snapshot.ParentVolume.Id)
+	}
+	d.Set("name", sl.Get(snapshot.Username, nil))
+	d.Set("notes", sl.Get(snapshot.Notes, nil))
+	d.Set("creation_time", sl.Get(snapshot.SnapshotCreationTimestamp, nil))
+	d.Set("size_bytes", sl.Get(snapshot.SnapshotSizeBytes, nil))
+
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("notes") {
+		_, err = services.GetNetworkStorageService(sess).
+			Id(id).
+			EditObject(&datatypes.Network_Storage{Notes: sl.String(d.Get("notes").(string))})
+		if err != nil {
+			return fmt.Errorf("Error updating storage snapshot notes: %s", err)
+		}
+	}
+
+	// The volume is restored when restore_to_volume changes to true. Changing it back to false does nothing.
+	if d.HasChange("restore_to_volume") && d.Get("restore_to_volume").(bool) {
+		err = restoreStorageSnapshot(sess, d.Get("volume_id").(int), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerStorageSnapshotRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = services.GetNetworkStorageService(sess).Id(id).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting storage snapshot: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = services.GetNetworkStorageService(sess).Id(id).GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving storage snapshot: %s", err)
+	}
+	return true, nil
+}
+
+func restoreStorageSnapshot(sess *session.Session, volumeId int, snapshotId int) error {
+	log.Printf("[INFO] Restoring storage (%d) from snapshot (%d)", volumeId, snapshotId)
+
+	_, err := services.GetNetworkStorageService(sess).
+		Id(volumeId).
+		RestoreFromSnapshot(sl.Int(snapshotId))
+	if err != nil {
+		return fmt.Errorf("Error restoring storage (%d) from snapshot (%d): %s", volumeId, snapshotId, err)
+	}
+
+	// The restore transaction is scheduled after RestoreFromSnapshot returns.
+	err = waitForStorageTransactionStart(sess, volumeId, 5*time.Minute)
+	if err == nil {
+		_, err = waitForStorageTransactions(sess, volumeId)
+	}
+	if err != nil {
+		return fmt.Errorf("Error waiting for storage (%d) to be restored: %s", volumeId, err)
+	}
+
+	return nil
+}
+
+// Waits until SoftLayer starts a transaction on the storage, like the transaction of a duplication which is
+// scheduled after the order completes. Short transactions may finish before they are seen, so the storage
+// is treated as ready if no transaction starts within the timeout.
+func waitForStorageTransactionStart(sess *session.Session, volumeId int, timeout time.Duration) error {
+	log.Printf("Waiting for a transaction of storage (%d) to start.", volumeId)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "pending"},
+		Target:  []string{"started"},
+		Refresh: func() (interface{}, string, error) {
+			transactions, err := services.GetNetworkStorageService(sess).
+				Id(volumeId).
+				GetActiveTransactions()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Error retrieving storage: %s", err)
+				}
+				return false, "retry", nil
+			}
+
+			if len(transactions) > 0 {
+				return transactions, "started", nil
+			}
+
+			return transactions, "pending", nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if _, ok := err.(*resource.TimeoutError); ok {
+		log.Printf("[WARN] No transaction of storage (%d) started within %s", volumeId, timeout)
+		return nil
+	}
+	return err
+}
+
+// Waits until the storage has no active transactions.
+func waitForStorageTransactions(sess *session.Session, volumeId int) (interface{}, error) {
+	log.Printf("Waiting for active transactions of storage (%d) to finish.", volumeId)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			transactions, err := services.GetNetworkStorageService(sess).
+				Id(volumeId).
+				GetActiveTransactions()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Error retrieving storage: %s", err)
+				}
+				return false, "retry", nil
+			}
+
+			if len(transactions) > 0 {
+				return transactions, "pending", nil
+			}
+
+			return transactions, "complete", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
@@ -0,0 +1,122 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccSoftLayerStorageSnapshot_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerStorageSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerStorageSnapshotConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerStorageSnapshotExists("softlayer_storage_snapshot.snapshot"),
+					testAccCheckSoftLayerResources("softlayer_storage_snapshot.snapshot", "volume_id",
+						"softlayer_block_storage.bs_endurance", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot.snapshot", "notes", "before migration"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_snapshot.snapshot", "name"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_snapshot.snapshot", "creation_time"),
+				),
+			},
+
+			{
+				Config: testAccCheckSoftLayerStorageSnapshotConfig_restore,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerStorageSnapshotExists("softlayer_storage_snapshot.snapshot"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot.snapshot", "notes", "restored"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot.snapshot", "restore_to_volume", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageSnapshotDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_storage_snapshot" {
+			continue
+		}
+
+		snapshotId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Try to find the snapshot
+		_, err := service.Id(snapshotId).GetObject()
+
+		if err == nil {
+			return fmt.Errorf("Storage snapshot %d still exists", snapshotId)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerStorageSnapshotExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		snapshotId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+		foundSnapshot, err := service.Id(snapshotId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*foundSnapshot.Id) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerStorageSnapshotConfig_volume = `
+resource "softlayer_block_storage" "bs_endurance" {
+        type = "Endurance"
+        datacenter = "dal06"
+        capacity = 20
+        iops = 0.25
+        snapshot_capacity = 10
+        os_format_type = "Linux"
+}
+`
+
+const testAccCheckSoftLayerStorageSnapshotConfig_basic = testAccCheckSoftLayerStorageSnapshotConfig_volume + `
+resource "softlayer_storage_snapshot" "snapshot" {
+        volume_id = "${softlayer_block_storage.bs_endurance.id}"
+        notes = "before migration"
+}
+`
+
+const testAccCheckSoftLayerStorageSnapshotConfig_restore = testAccCheckSoftLayerStorageSnapshotConfig_volume + `
+resource "softlayer_storage_snapshot" "snapshot" {
+        volume_id = "${softlayer_block_storage.bs_endurance.id}"
+        notes = "restored"
+        restore_to_volume = true
+}
+`
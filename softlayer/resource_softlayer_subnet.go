@@ -0,0 +1,428 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	AdditionalServicesPortableIpAddressesPackageType = "ADDITIONAL_SERVICES_PORTABLE_IP_ADDRESSES"
+	AdditionalServicesStaticIpAddressesPackageType   = "ADDITIONAL_SERVICES_STATIC_IP_ADDRESSES"
+
+	SubnetMask = "id,note,cidr,gateway,networkIdentifier,version,subnetType,addressSpace,totalIpAddresses," +
+		"networkVlanId,endPointIpAddress[ipAddress]," +
+		"ipAddresses[ipAddress,isNetwork,isGateway,isBroadcast]"
+)
+
+func resourceSoftLayerSubnet() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerSubnetCreate,
+		Read:     resourceSoftLayerSubnetRead,
+		Update:   resourceSoftLayerSubnetUpdate,
+		Delete:   resourceSoftLayerSubnetDelete,
+		Exists:   resourceSoftLayerSubnetExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					subnetType := v.(string)
+					if subnetType != "PORTABLE" && subnetType != "STATIC" {
+						errs = append(errs, errors.New(
+							"subnet type should be either 'PORTABLE' or 'STATIC'"))
+					}
+					return
+				},
+			},
+
+			"private": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"ip_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  4,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					ipVersion := v.(int)
+					if ipVersion != 4 && ipVersion != 6 {
+						errs = append(errs, errors.New(
+							"ip version should be either 4 or 6"))
+					}
+					return
+				},
+			},
+
+			"capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vlan_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ConflictsWith: []string{"endpoint_ip"},
+			},
+
+			"endpoint_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ConflictsWith: []string{"vlan_id"},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					address := v.(string)
+					if net.ParseIP(address) == nil {
+						errs = append(errs, fmt.Errorf("Invalid IP format: %s", address))
+					}
+					return
+				},
+				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+					newEndpointIp := net.ParseIP(n)
+					return newEndpointIp != nil && (newEndpointIp.String() == net.ParseIP(o).String())
+				},
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"subnet_cidr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"gateway_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"usable_ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerSubnetCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+
+	subnetType := d.Get("type").(string)
+	if subnetType == "PORTABLE" && d.Get("vlan_id").(int) == 0 {
+		return fmt.Errorf("vlan_id is required for portable subnets")
+	}
+	if subnetType == "STATIC" && d.Get("endpoint_ip").(string) == "" {
+		return fmt.Errorf("endpoint_ip is required for static subnets")
+	}
+	if subnetType == "STATIC" && d.Get("private").(bool) {
+		return fmt.Errorf("Static subnets can only be public")
+	}
+
+	packageType := AdditionalServicesPortableIpAddressesPackageType
+	if subnetType == "STATIC" {
+		packageType = AdditionalServicesStaticIpAddressesPackageType
+	}
+
+	// Find price items with AdditionalServicesPortableIpAddresses or AdditionalServicesStaticIpAddresses
+	productOrderContainer, err := buildSubnetProductOrderContainer(d, sess, packageType)
+	if err != nil {
+		// Find price items with AdditionalServices
+		productOrderContainer, err = buildSubnetProductOrderContainer(d, sess, AdditionalServicesPackageType)
+		if err != nil {
+			return fmt.Errorf("Error creating subnet: %s", err)
+		}
+	}
+
+	log.Println("[INFO] Creating subnet")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of subnet: %s", err)
+	}
+
+	subnet, err := findSubnetByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of subnet: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *subnet.Id))
+
+	if notes, ok := d.GetOk("notes"); ok {
+		_, err = services.GetNetworkSubnetService(sess).Id(*subnet.Id).EditNote(sl.String(notes.(string)))
+		if err != nil {
+			return fmt.Errorf("Error updating subnet notes: %s", err)
+		}
+	}
+
+	return resourceSoftLayerSubnetRead(d, meta)
+}
+
+func resourceSoftLayerSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	subnet, err := service.Id(subnetId).Mask(SubnetMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving subnet: %s", err)
+	}
+
+	if strings.Contains(sl.Get(subnet.SubnetType, "").(string), "STATIC") {
+		d.Set("type", "STATIC")
+	} else {
+		d.Set("type", "PORTABLE")
+	}
+	d.Set("private", sl.Get(subnet.AddressSpace, "") == "PRIVATE")
+	d.Set("ip_version", sl.Get(subnet.Version, 4))
+	d.Set("notes", sl.Get(subnet.Note, nil))
+
+	// IPv6 subnets are ordered by their prefix length, IPv4 subnets by their number of addresses.
+	if sl.Get(subnet.Version, 4) == 6 {
+		d.Set("capacity", sl.Get(subnet.Cidr, nil))
+	} else if subnet.TotalIpAddresses != nil {
+		d.Set("capacity", int(*subnet.TotalIpAddresses))
+	}
+
+	if subnet.EndPointIpAddress != nil {
+		d.Set("endpoint_ip", *subnet.EndPointIpAddress.IpAddress)
+	} else {
+		d.Set("vlan_id", sl.Get(subnet.NetworkVlanId, nil))
+	}
+
+	d.Set("subnet_cidr", fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr))
+	d.Set("gateway_ip", sl.Get(subnet.Gateway, nil))
+
+	d.Set("usable_ip_addresses", getUsableIpAddresses(subnet.IpAddresses))
+
+	return nil
+}
+
+func resourceSoftLayerSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("notes") {
+		_, err = service.Id(subnetId).EditNote(sl.String(d.Get("notes").(string)))
+		if err != nil {
+			return fmt.Errorf("Error updating subnet notes: %s", err)
+		}
+	}
+
+	return resourceSoftLayerSubnetRead(d, meta)
+}
+
+func resourceSoftLayerSubnetDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(subnetId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting subnet: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	success, err := services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("Error deleting subnet: %s", err)
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful cancellation")
+	}
+
+	return nil
+}
+
+func resourceSoftLayerSubnetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ProviderConfig).SoftLayerSession()
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(subnetId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving subnet: %s", err)
+	}
+	return result.Id != nil && *result.Id == subnetId, nil
+}
+
+// Returns the IP addresses of a subnet except the network, gateway and broadcast addresses.
+func getUsableIpAddresses(ipAddresses []datatypes.Network_Subnet_IpAddress) []string {
+	usableIpAddresses := make([]string, 0, len(ipAddresses))
+	for _, ipAddress := range ipAddresses {
+		if sl.Get(ipAddress.IsNetwork, false).(bool) ||
+			sl.Get(ipAddress.IsGateway, false).(bool) ||
+			sl.Get(ipAddress.IsBroadcast, false).(bool) {
+			continue
+		}
+		usableIpAddresses = append(usableIpAddresses, *ipAddress.IpAddress)
+	}
+	return usableIpAddresses
+}
+
+func findSubnetByOrderId(sess *session.Session, orderId int) (datatypes.Network_Subnet, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			subnets, err := services.GetAccountService(sess).
+				Filter(filter.Path("subnets.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id,activeTransaction[id]").
+				GetSubnets()
+			if err != nil {
+				return datatypes.Network_Subnet{}, "", err
+			}
+
+			if len(subnets) == 1 && subnets[0].ActiveTransaction == nil {
+				return subnets[0], "complete", nil
+			} else if len(subnets) == 0 || len(subnets) == 1 {
+				return datatypes.Network_Subnet{}, "pending", nil
+			} else {
+				return datatypes.Network_Subnet{}, "", fmt.Errorf("Expected one subnet for order %d, found %d", orderId, len(subnets))
+			}
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Subnet{}, err
+	}
+
+	if result, ok := pendingResult.(datatypes.Network_Subnet); ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Subnet{},
+		fmt.Errorf("Cannot find subnet with order id '%d'", orderId)
+}
+
+func buildSubnetProductOrderContainer(d *schema.ResourceData, sess *session.Session, packageType string) (
+	*datatypes.Container_Product_Order_Network_Subnet, error) {
+
+	// 1. Get a package
+	pkg, err := product.GetPackageByType(sess, packageType)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Network_Subnet{}, err
+	}
+
+	// 2. Get all prices for the package
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Network_Subnet{}, err
+	}
+
+	// 3. Find subnet prices
+	subnetType := d.Get("type").(string)
+	capacity := d.Get("capacity").(int)
+	addressSpace := "PUBLIC"
+	if d.Get("private").(bool) {
+		addressSpace = "PRIVATE"
+	}
+
+	var subnetKeyname string
+	if d.Get("ip_version").(int) == 6 {
+		// e.g. 64_BLOCK_PORTABLE_PUBLIC_IPV6_ADDRESSES
+		subnetKeyname = fmt.Sprintf("%d_BLOCK_%s_%s_IPV6_ADDRESSES", capacity, subnetType, addressSpace)
+	} else {
+		// e.g. 8_PORTABLE_PRIVATE_IP_ADDRESSES
+		subnetKeyname = fmt.Sprintf("%d_%s_%s_IP_ADDRESSES", capacity, subnetType, addressSpace)
+	}
+
+	// 4. Select items with a matching keyname
+	subnetItems := []datatypes.Product_Item{}
+	for _, item := range productItems {
+		if *item.KeyName == subnetKeyname {
+			subnetItems = append(subnetItems, item)
+		}
+	}
+
+	if len(subnetItems) == 0 {
+		return &datatypes.Container_Product_Order_Network_Subnet{},
+			fmt.Errorf("No product items matching %s could be found", subnetKeyname)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Subnet{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: subnetItems[0].Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+	}
+
+	// 5. Set the target of the subnet
+	if subnetType == "PORTABLE" {
+		productOrderContainer.EndPointVlanId = sl.Int(d.Get("vlan_id").(int))
+	} else {
+		endpointIp := d.Get("endpoint_ip").(string)
+		ipAddress, err := services.GetNetworkSubnetIpAddressService(sess).
+			Mask("id").
+			GetByIpAddress(sl.String(endpointIp))
+		if err != nil || ipAddress.Id == nil {
+			return &datatypes.Container_Product_Order_Network_Subnet{},
+				fmt.Errorf("Error looking up endpoint ip address %s: %v", endpointIp, err)
+		}
+		productOrderContainer.EndPointIpAddressId = ipAddress.Id
+	}
+
+	return &productOrderContainer, nil
+}
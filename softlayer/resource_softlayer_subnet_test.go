@@ -0,0 +1,142 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccSoftLayerSubnet_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerSubnetConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSubnetExists("softlayer_subnet.portable_subnet"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable_subnet", "type", "PORTABLE"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable_subnet", "private", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable_subnet", "capacity", "8"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable_subnet", "notes", "portable_subnet"),
+					resource.TestMatchResourceAttr(
+						"softlayer_subnet.portable_subnet", "subnet_cidr", regexp.MustCompile("^[0-9.]+/29$")),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable_subnet", "usable_ip_addresses.#", "5"),
+
+					testAccCheckSoftLayerSubnetExists("softlayer_subnet.static_subnet"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.static_subnet", "type", "STATIC"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.static_subnet", "capacity", "4"),
+					resource.TestMatchResourceAttr(
+						"softlayer_subnet.static_subnet", "subnet_cidr", regexp.MustCompile("^[0-9.]+/30$")),
+				),
+			},
+
+			{
+				Config: testAccCheckSoftLayerSubnetConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable_subnet", "notes", "portable_subnet_updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerSubnetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		subnetId, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		service := services.GetNetworkSubnetService(testAccProvider.Meta().(ProviderConfig).SoftLayerSession())
+		subnet, err := service.Id(subnetId).Mask("id").GetObject()
+		if err != nil {
+			return err
+		}
+
+		if *subnet.Id != subnetId {
+			return fmt.Errorf("Subnet not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerSubnetConfig_guest = `
+resource "softlayer_virtual_guest" "subnetvm1" {
+    hostname = "subnetvm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+`
+
+const testAccCheckSoftLayerSubnetConfig_basic = testAccCheckSoftLayerSubnetConfig_guest + `
+resource "softlayer_subnet" "portable_subnet" {
+    type = "PORTABLE"
+    private = true
+    ip_version = 4
+    capacity = 8
+    vlan_id = "${softlayer_virtual_guest.subnetvm1.private_vlan_id}"
+    notes = "portable_subnet"
+}
+
+resource "softlayer_subnet" "static_subnet" {
+    type = "STATIC"
+    private = false
+    ip_version = 4
+    capacity = 4
+    endpoint_ip = "${softlayer_virtual_guest.subnetvm1.ipv4_address}"
+    notes = "static_subnet"
+}
+`
+
+const testAccCheckSoftLayerSubnetConfig_update = testAccCheckSoftLayerSubnetConfig_guest + `
+resource "softlayer_subnet" "portable_subnet" {
+    type = "PORTABLE"
+    private = true
+    ip_version = 4
+    capacity = 8
+    vlan_id = "${softlayer_virtual_guest.subnetvm1.private_vlan_id}"
+    notes = "portable_subnet_updated"
+}
+
+resource "softlayer_subnet" "static_subnet" {
+    type = "STATIC"
+    private = false
+    ip_version = 4
+    capacity = 4
+    endpoint_ip = "${softlayer_virtual_guest.subnetvm1.ipv4_address}"
+    notes = "static_subnet"
+}
+`
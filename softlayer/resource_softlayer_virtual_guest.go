@@ -295,6 +295,12 @@ func resourceSoftLayerVirtualGuest() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"root_password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"ssh_key_ids": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -698,6 +704,7 @@ func resourceSoftLayerVirtualGuestRead(d *schema.ResourceData, meta interface{})
 			"dedicatedHost[id,name]," +
 			"primaryIpAddress,primaryBackendIpAddress,privateNetworkOnlyFlag," +
 			"operatingSystemReferenceCode,blockDeviceTemplateGroup[id]," +
+			"operatingSystem[passwords[username,password]]," +
 			"hourlyBillingFlag,localDiskFlag," +
 			"notes,userData[value],tagReferences[id,tag[name]]," +
 			"datacenter[id,name,longName]," +
@@ -815,6 +822,13 @@ func resourceSoftLayerVirtualGuestRead(d *schema.ResourceData, meta interface{})
 		d.Set("tags", tags)
 	}
 
+	var osPasswords []datatypes.Software_Component_Password
+	if result.OperatingSystem != nil {
+		osPasswords = result.OperatingSystem.Passwords
+	}
+	rootUsername, rootPassword := getRootCredentials(osPasswords)
+	d.Set("root_password", rootPassword)
+
 	// Set connection info
 	connInfo := map[string]string{"type": "ssh"}
 	if !*result.PrivateNetworkOnlyFlag && result.PrimaryIpAddress != nil {
@@ -822,6 +836,10 @@ func resourceSoftLayerVirtualGuestRead(d *schema.ResourceData, meta interface{})
 	} else {
 		connInfo["host"] = *result.PrimaryBackendIpAddress
 	}
+	if rootPassword != "" {
+		connInfo["user"] = rootUsername
+		connInfo["password"] = rootPassword
+	}
 	d.SetConnInfo(connInfo)
 
 	// Read secondary IP addresses
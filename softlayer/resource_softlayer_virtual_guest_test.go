@@ -48,6 +48,8 @@ func TestAccSoftLayerVirtualGuest_Basic(t *testing.T) {
 						"softlayer_virtual_guest.terraform-acceptance-test-1", "disks.1", "10"),
 					resource.TestCheckResourceAttr(
 						"softlayer_virtual_guest.terraform-acceptance-test-1", "disks.2", "20"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_virtual_guest.terraform-acceptance-test-1", "root_password"),
 					resource.TestCheckResourceAttr(
 						"softlayer_virtual_guest.terraform-acceptance-test-1", "user_metadata", "{\"value\":\"newvalue\"}"),
 					resource.TestCheckResourceAttr(